@@ -0,0 +1,119 @@
+package redismock
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Blocking parks the command matching an expectation, as Redis blocks BLPOP, BRPOP, BLMOVE, BZPOPMIN
+// or XREAD with BLOCK until there is data. The command returns the value, error or redis.Nil set on
+// the expectation once it is released, redis.Nil when its own timeout passes on the Clock of the mock,
+// and the error of its context when the context is cancelled or the connection to a Server is closed.
+type Blocking struct {
+	parked   chan struct{}
+	released chan struct{}
+
+	parkOnce    sync.Once
+	releaseOnce sync.Once
+}
+
+// Block parks the command matching the expectation until Release. The value of the expectation
+// is set before Release, a command released beforehand does not wait.
+func (base *expectedBase) Block() *Blocking {
+	base.blocking = &Blocking{
+		parked:   make(chan struct{}),
+		released: make(chan struct{}),
+	}
+	return base.blocking
+}
+
+func (base *expectedBase) blocker() *Blocking {
+	return base.blocking
+}
+
+// Parked returns a channel closed when the command is parked.
+func (b *Blocking) Parked() <-chan struct{} {
+	return b.parked
+}
+
+// Release unblocks the command with the value of the expectation.
+func (b *Blocking) Release() {
+	b.releaseOnce.Do(func() {
+		close(b.released)
+	})
+}
+
+// wait parks the command until it is released, its timeout passes or ctx is done.
+func (b *Blocking) wait(ctx context.Context, clock *Clock, cmd redis.Cmder) error {
+	var deadline time.Time
+	if timeout := blockTimeout(formatArgs(cmd.Args())); timeout > 0 {
+		deadline = clock.Now().Add(timeout)
+	}
+	// a single timer is re-armed on every change of the clock
+	var expired <-chan time.Time
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		// the channel is taken before the clock is read, so that a move in between is not missed,
+		// and the command is parked from then on
+		moved := clock.changed()
+		b.parkOnce.Do(func() {
+			close(b.parked)
+		})
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if !deadline.IsZero() {
+			left := deadline.Sub(clock.Now())
+			if left <= 0 {
+				return redis.Nil
+			}
+			timer.Reset(left)
+			expired = timer.C
+		}
+
+		select {
+		case <-b.released:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-expired:
+		case <-moved:
+		}
+	}
+}
+
+// blockTimeout returns the timeout of a blocking command, 0 blocks indefinitely.
+func blockTimeout(args []string) time.Duration {
+	var timeout string
+	unit := time.Second
+	switch strings.ToLower(args[0]) {
+	case "blpop", "brpop", "brpoplpush", "blmove", "bzpopmin", "bzpopmax":
+		timeout = args[len(args)-1]
+	case "blmpop", "bzmpop":
+		if len(args) > 1 {
+			timeout = args[1]
+		}
+	case "xread", "xreadgroup":
+		for i := 1; i+1 < len(args) && !strings.EqualFold(args[i], "streams"); i++ {
+			if strings.EqualFold(args[i], "block") {
+				timeout, unit = args[i+1], time.Millisecond
+			}
+		}
+	}
+
+	n, err := strconv.ParseFloat(timeout, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	return time.Duration(n * float64(unit))
+}
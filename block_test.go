@@ -0,0 +1,165 @@
+package redismock
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Block", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientMock()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("release", func() {
+		e := clientMock.ExpectBLPop(0, "queue")
+		b := e.Block()
+		clientMock.ExpectSet("key", "value", 0).SetVal("OK")
+
+		done := make(chan []string)
+		go func() {
+			defer GinkgoRecover()
+			val, err := client.BLPop(ctx, 0, "queue").Result()
+			Expect(err).NotTo(HaveOccurred())
+			done <- val
+		}()
+
+		<-b.Parked()
+		// the other commands are processed meanwhile
+		Expect(client.Set(ctx, "key", "value", 0).Val()).To(Equal("OK"))
+		Consistently(done).ShouldNot(Receive())
+
+		e.SetVal([]string{"queue", "job"})
+		b.Release()
+		Eventually(done).Should(Receive(Equal([]string{"queue", "job"})))
+
+		// released beforehand
+		e = clientMock.ExpectBLPop(0, "queue")
+		e.SetVal([]string{"queue", "next"})
+		e.Block().Release()
+		Expect(client.BLPop(ctx, 0, "queue").Val()).To(Equal([]string{"queue", "next"}))
+	})
+
+	It("timeout", func() {
+		b := clientMock.ExpectBRPop(time.Minute, "queue").Block()
+		done := make(chan error)
+		go func() {
+			done <- client.BRPop(ctx, time.Minute, "queue").Err()
+		}()
+
+		<-b.Parked()
+		clientMock.Clock().Advance(59 * time.Second)
+		Consistently(done).ShouldNot(Receive())
+		clientMock.Clock().Advance(time.Second)
+		Eventually(done).Should(Receive(Equal(redis.Nil)))
+
+		// the wall clock
+		clientMock.ExpectXRead(&redis.XReadArgs{Streams: []string{"stream", "$"}, Block: 50 * time.Millisecond}).Block()
+		begin := time.Now()
+		Expect(client.XRead(ctx, &redis.XReadArgs{Streams: []string{"stream", "$"}, Block: 50 * time.Millisecond}).Err()).To(
+			Equal(redis.Nil))
+		Expect(time.Since(begin)).To(BeNumerically(">=", 50*time.Millisecond))
+	})
+
+	It("advance once parked", func() {
+		// a move of the frozen clock right after the command is parked is not missed
+		clientMock.Clock().Set(time.Unix(1700000000, 0))
+		for i := 0; i < 1000; i++ {
+			b := clientMock.ExpectBRPop(time.Minute, "queue").Block()
+			done := make(chan error, 1)
+			go func() {
+				waitCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+				defer cancel()
+				done <- client.BRPop(waitCtx, time.Minute, "queue").Err()
+			}()
+
+			<-b.Parked()
+			clientMock.Clock().Advance(time.Minute)
+			Expect(<-done).To(Equal(redis.Nil), "iteration %d", i)
+		}
+	})
+
+	It("shutdown", func() {
+		// a worker polls the queue until it is stopped
+		e := clientMock.ExpectBLMove("queue", "processing", "LEFT", "RIGHT", 0)
+		e.SetVal("job")
+		e.Block().Release()
+		b := clientMock.ExpectBLMove("queue", "processing", "LEFT", "RIGHT", 0).Block()
+
+		workerCtx, stop := context.WithCancel(ctx)
+		jobs := make(chan string, 1)
+		done := make(chan error)
+		go func() {
+			for {
+				job, err := client.BLMove(workerCtx, "queue", "processing", "LEFT", "RIGHT", 0).Result()
+				if err != nil {
+					done <- err
+					return
+				}
+				jobs <- job
+			}
+		}()
+
+		Eventually(jobs).Should(Receive(Equal("job")))
+		<-b.Parked()
+		stop()
+		Eventually(done).Should(Receive(Equal(context.Canceled)))
+	})
+
+	It("timeouts", func() {
+		for _, c := range []struct {
+			args    []string
+			timeout time.Duration
+		}{
+			{[]string{"blpop", "a", "b", "1.5"}, 1500 * time.Millisecond},
+			{[]string{"bzpopmin", "z", "0"}, 0},
+			{[]string{"blmpop", "2", "1", "list", "left"}, 2 * time.Second},
+			{[]string{"xread", "count", "1", "block", "100", "streams", "s", "0"}, 100 * time.Millisecond},
+			{[]string{"xread", "streams", "block", "0"}, 0},
+			{[]string{"get", "key"}, 0},
+		} {
+			Expect(blockTimeout(c.args)).To(Equal(c.timeout), c.args[0])
+		}
+	})
+
+	It("server", func() {
+		srv, err := NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+		remote := redis.NewClient(srv.Options())
+		defer remote.Close()
+
+		e := clientMock.ExpectBZPopMin(0, "zset")
+		b := e.Block()
+		done := make(chan *redis.ZWithKey)
+		go func() {
+			defer GinkgoRecover()
+			val, err := remote.BZPopMin(ctx, 0, "zset").Result()
+			Expect(err).NotTo(HaveOccurred())
+			done <- val
+		}()
+
+		<-b.Parked()
+		e.SetVal(&redis.ZWithKey{Key: "zset", Z: redis.Z{Score: 1, Member: "a"}})
+		b.Release()
+		Eventually(done).Should(Receive(Equal(&redis.ZWithKey{Key: "zset", Z: redis.Z{Score: 1, Member: "a"}})))
+
+		// closing the server returns the blocked commands
+		b = clientMock.ExpectBLPop(0, "queue").Block()
+		go remote.BLPop(ctx, 0, "queue")
+		<-b.Parked()
+		Expect(srv.Close()).NotTo(HaveOccurred())
+	})
+})
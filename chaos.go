@@ -0,0 +1,190 @@
+package redismock
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ChaosFault is a kind of fault injected by Chaos.
+type ChaosFault string
+
+const (
+	// ChaosError fails the command with a server error of the policy.
+	ChaosError ChaosFault = "error"
+	// ChaosTimeout fails the command with a network timeout, as when the read deadline passes.
+	ChaosTimeout ChaosFault = "timeout"
+	// ChaosReset fails the command with io.EOF or a connection reset, as when the server closes the connection.
+	ChaosReset ChaosFault = "reset"
+	// ChaosLatency delays the command, which is then processed.
+	ChaosLatency ChaosFault = "latency"
+)
+
+// ChaosPolicy selects the commands faulted by Chaos and the faults injected.
+type ChaosPolicy struct {
+	// Rate is the fraction of the selected commands faulted, from 0 to 1.
+	Rate float64
+
+	// Faults are the kinds of faults, one is chosen at random for a faulted command. All by default.
+	Faults []ChaosFault
+
+	// Errors are the errors of ChaosError, one is chosen at random.
+	// LOADING, TRYAGAIN and OOM by default.
+	Errors []error
+
+	// Latency is the maximum delay of ChaosLatency, 100ms by default.
+	Latency time.Duration
+
+	// Commands are the names of the commands selected, all by default.
+	Commands []string
+
+	// Keys are the glob-style patterns of the keys of the commands selected, all the commands by default.
+	// A command is selected if one of its keys matches.
+	Keys []string
+}
+
+// ChaosEvent is a fault injected by Chaos.
+type ChaosEvent struct {
+	// Seq is the position of the command among the commands selected by the policy, from 0.
+	Seq   int
+	Args  []interface{}
+	Fault ChaosFault
+	// Err is the error of the command, nil for ChaosLatency.
+	Err     error
+	Latency time.Duration
+}
+
+// chaos injects the faults of a policy, the random choices follow the seed.
+type chaos struct {
+	mu     sync.Mutex
+	rnd    *rand.Rand
+	policy *ChaosPolicy
+	seq    int
+	events []ChaosEvent
+}
+
+// Chaos injects faults into a fraction of the commands of the client, at random from the seed: errors,
+// timeouts, connection resets or latency. A run is reproducible from the seed as long as the commands
+// are sent in the same order. The faulted commands are not processed by the mock, except for the latency.
+// A nil policy stops the injection, ChaosReport returns the faults injected.
+func (m *mock) Chaos(seed int64, policy *ChaosPolicy) {
+	c := m.chaos
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rnd, c.policy, c.seq, c.events = nil, nil, 0, nil
+	if policy == nil {
+		return
+	}
+
+	p := *policy
+	if len(p.Faults) == 0 {
+		p.Faults = []ChaosFault{ChaosError, ChaosTimeout, ChaosReset, ChaosLatency}
+	}
+	if len(p.Errors) == 0 {
+		p.Errors = []error{LoadingError(), TryAgainError(), OOMError()}
+	}
+	if p.Latency <= 0 {
+		p.Latency = 100 * time.Millisecond
+	}
+	c.rnd, c.policy = rand.New(rand.NewSource(seed)), &p
+}
+
+// ChaosReport returns the faults injected since Chaos was called, in order.
+func (m *mock) ChaosReport() []ChaosEvent {
+	c := m.chaos
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ChaosEvent(nil), c.events...)
+}
+
+// inject applies the chaos to a command of the hook, the command is not processed
+// if an error is returned.
+func (c *chaos) inject(ctx context.Context, cmd redis.Cmder) error {
+	e, ok := c.fault(cmd)
+	if !ok {
+		return nil
+	}
+
+	err := e.Err
+	if e.Fault == ChaosLatency {
+		t := time.NewTimer(e.Latency)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			return nil
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	cmd.SetErr(err)
+	return err
+}
+
+// fault draws the fault of a command, ok is false if the command is not faulted.
+func (c *chaos) fault(cmd redis.Cmder) (e ChaosEvent, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.policy == nil || !c.selects(formatArgs(cmd.Args())) {
+		return e, false
+	}
+	e.Seq = c.seq
+	c.seq++
+	if c.rnd.Float64() >= c.policy.Rate {
+		return e, false
+	}
+
+	e.Args = append([]interface{}(nil), cmd.Args()...)
+	e.Fault = c.policy.Faults[c.rnd.Intn(len(c.policy.Faults))]
+	switch e.Fault {
+	case ChaosError:
+		e.Err = c.policy.Errors[c.rnd.Intn(len(c.policy.Errors))]
+	case ChaosTimeout:
+		e.Err = &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	case ChaosReset:
+		e.Err = io.EOF
+		if c.rnd.Intn(2) == 1 {
+			e.Err = &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		}
+	case ChaosLatency:
+		e.Latency = time.Duration(c.rnd.Int63n(int64(c.policy.Latency)) + 1)
+	}
+	c.events = append(c.events, e)
+	return e, true
+}
+
+// selects tells whether the policy selects the command by its name and its keys.
+func (c *chaos) selects(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if len(c.policy.Commands) > 0 {
+		var found bool
+		for _, name := range c.policy.Commands {
+			found = found || strings.EqualFold(name, args[0])
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(c.policy.Keys) == 0 {
+		return true
+	}
+	for _, key := range commandKeys(args) {
+		for _, pattern := range c.policy.Keys {
+			if globMatch(pattern, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package redismock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Chaos", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientFakeMock()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("reproducible", func() {
+		policy := &ChaosPolicy{Rate: 0.3, Faults: []ChaosFault{ChaosError, ChaosTimeout, ChaosReset}}
+		run := func(seed int64) ([]ChaosEvent, []error) {
+			clientMock.Chaos(seed, policy)
+			var errs []error
+			for i := 0; i < 50; i++ {
+				errs = append(errs, client.Set(ctx, fmt.Sprintf("key:%d", i), i, 0).Err())
+			}
+			return clientMock.ChaosReport(), errs
+		}
+
+		report, errs := run(42)
+		// the verification is not faulted
+		clientMock.Chaos(0, nil)
+		Expect(len(report)).To(BeNumerically(">", 0))
+		Expect(len(report)).To(BeNumerically("<", 50))
+		faulted := map[int]bool{}
+		for _, e := range report {
+			faulted[e.Seq] = true
+			Expect(errs[e.Seq]).To(Equal(e.Err))
+			Expect(e.Args[1]).To(Equal(fmt.Sprintf("key:%d", e.Seq)))
+		}
+		for i := 0; i < 50; i++ {
+			exists := int64(1)
+			if faulted[i] {
+				exists = 0
+			}
+			Expect(client.Exists(ctx, fmt.Sprintf("key:%d", i)).Val()).To(Equal(exists), "key:%d", i)
+		}
+
+		Expect(client.FlushAll(ctx).Err()).NotTo(HaveOccurred())
+		again, _ := run(42)
+		Expect(again).To(Equal(report))
+		other, _ := run(7)
+		Expect(other).NotTo(Equal(report))
+	})
+
+	It("faults", func() {
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosError}})
+		err := client.Set(ctx, "key", "value", 0).Err()
+		_, ok := err.(redis.Error)
+		Expect(ok).To(BeTrue())
+		Expect(err).To(Or(MatchError(LoadingError()), MatchError(TryAgainError()), MatchError(OOMError())))
+
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosError}, Errors: []error{BusyError()}})
+		Expect(client.Get(ctx, "key").Err()).To(MatchError(BusyError()))
+
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosTimeout}})
+		err = client.Get(ctx, "key").Err()
+		var netErr net.Error
+		Expect(errors.As(err, &netErr)).To(BeTrue())
+		Expect(netErr.Timeout()).To(BeTrue())
+
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosReset}})
+		for i := 0; i < 10; i++ {
+			err = client.Get(ctx, "key").Err()
+			Expect(errors.Is(err, io.EOF) || errors.Is(err, syscall.ECONNRESET)).To(BeTrue(), err.Error())
+		}
+
+		// the faulted commands are not processed
+		clientMock.Chaos(1, nil)
+		Expect(client.Exists(ctx, "key").Val()).To(BeZero())
+	})
+
+	It("latency", func() {
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosLatency}, Latency: 20 * time.Millisecond})
+		begin := time.Now()
+		Expect(client.Set(ctx, "key", "value", 0).Val()).To(Equal("OK"))
+
+		report := clientMock.ChaosReport()
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].Fault).To(Equal(ChaosLatency))
+		Expect(report[0].Err).NotTo(HaveOccurred())
+		Expect(report[0].Latency).To(BeNumerically("<=", 20*time.Millisecond))
+		Expect(time.Since(begin)).To(BeNumerically(">=", report[0].Latency))
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(client.Get(cancelled, "key").Err()).To(Equal(context.Canceled))
+	})
+
+	It("filters", func() {
+		clientMock.Chaos(1, &ChaosPolicy{
+			Rate: 1, Faults: []ChaosFault{ChaosError}, Commands: []string{"GET"}, Keys: []string{"user:*"},
+		})
+		Expect(client.Set(ctx, "user:1", "alice", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "session:1").Err()).To(Equal(redis.Nil))
+		Expect(client.Get(ctx, "user:1").Err()).To(HaveOccurred())
+		Expect(client.MGet(ctx, "user:1").Val()).To(Equal([]interface{}{"alice"}))
+
+		report := clientMock.ChaosReport()
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].Seq).To(BeZero())
+		Expect(report[0].Args).To(Equal([]interface{}{"get", "user:1"}))
+	})
+
+	It("pipeline", func() {
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosError}, Commands: []string{"incr"}})
+		pipe := client.Pipeline()
+		set := pipe.Set(ctx, "a", "1", 0)
+		incr := pipe.Incr(ctx, "b")
+		get := pipe.Get(ctx, "a")
+		_, err := pipe.Exec(ctx)
+		Expect(err).To(Equal(incr.Err()))
+		Expect(set.Val()).To(Equal("OK"))
+		Expect(get.Val()).To(Equal("1"))
+
+		// the connection is lost, the following commands fail
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosReset}, Commands: []string{"incr"}})
+		pipe = client.Pipeline()
+		set = pipe.Set(ctx, "c", "1", 0)
+		incr = pipe.Incr(ctx, "b")
+		get = pipe.Get(ctx, "a")
+		_, err = pipe.Exec(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(set.Err()).NotTo(HaveOccurred())
+		Expect(incr.Err()).To(Equal(err))
+		Expect(get.Err()).To(Equal(err))
+		Expect(client.Exists(ctx, "b").Val()).To(BeZero())
+	})
+
+	It("expectations", func() {
+		mockClient, mock := NewClientMock()
+		defer mockClient.Close()
+
+		mock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosTimeout}, Keys: []string{"flaky"}})
+		mock.ExpectGet("stable").SetVal("value")
+		mock.ExpectGet("flaky").SetVal("value")
+
+		Expect(mockClient.Get(ctx, "stable").Val()).To(Equal("value"))
+		Expect(mockClient.Get(ctx, "flaky").Err()).To(HaveOccurred())
+		// the faulted command did not consume its expectation
+		Expect(mock.ExpectationsWereMet()).To(HaveOccurred())
+
+		mock.Chaos(1, nil)
+		Expect(mock.ChaosReport()).To(BeEmpty())
+		Expect(mockClient.Get(ctx, "flaky").Val()).To(Equal("value"))
+		Expect(mock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+})
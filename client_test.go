@@ -358,6 +358,74 @@ var _ = Describe("Client", func() {
 			clientMock.ClearExpect()
 		})
 
+		It("custom match calling the mock", func() {
+			clientMock.CustomMatch(func(expected, actual []interface{}) error {
+				if clientMock.ExpectationsWereMet() == nil {
+					return errors.New("all expectations were met")
+				}
+				return nil
+			}).ExpectGet("key").SetVal("value")
+
+			done := make(chan string, 1)
+			go func() {
+				done <- client.Get(ctx, "key").Val()
+			}()
+			Eventually(done, time.Second).Should(Receive(Equal("value")))
+		})
+
+	})
+
+	Describe("generic expect", func() {
+
+		AfterEach(func() {
+			hasUnexpectedCall, unexpectedCalls := clientMock.UnexpectedCallsWereMade()
+			Expect(hasUnexpectedCall).To(BeFalse())
+			Expect(unexpectedCalls).To(BeNil())
+		})
+
+		It("typed value", func() {
+			ExpectCmd(clientMock, func(c redis.Cmdable) *redis.StringCmd {
+				return c.Get(ctx, "key")
+			}).SetVal("value")
+			ExpectCmd(clientMock, func(c redis.Cmdable) *redis.ZSliceCmd {
+				return c.ZRangeWithScores(ctx, "zset", 0, -1)
+			}).SetVal([]redis.Z{{Score: 1, Member: "one"}})
+
+			get := client.Get(ctx, "key")
+			Expect(get.Err()).NotTo(HaveOccurred())
+			Expect(get.Val()).To(Equal("value"))
+
+			zRange := client.ZRangeWithScores(ctx, "zset", 0, -1)
+			Expect(zRange.Err()).NotTo(HaveOccurred())
+			Expect(zRange.Val()).To(Equal([]redis.Z{{Score: 1, Member: "one"}}))
+		})
+
+		It("commands outside of Cmdable", func() {
+			ExpectCmd(clientMock, func(c redis.Cmdable) *redis.XMessageSliceCmd {
+				return c.(redis.StreamCmdable).XRange(ctx, "stream", "-", "+")
+			}).SetVal([]redis.XMessage{{ID: "1-0", Values: map[string]interface{}{"k": "v"}}})
+			ExpectCmd(clientMock, func(c redis.Cmdable) *redis.Cmd {
+				return c.(*redis.Client).Do(ctx, "module.cmd", "key")
+			}).RedisNil()
+
+			xRange := client.XRange(ctx, "stream", "-", "+")
+			Expect(xRange.Err()).NotTo(HaveOccurred())
+			Expect(xRange.Val()).To(Equal([]redis.XMessage{{ID: "1-0", Values: map[string]interface{}{"k": "v"}}}))
+
+			do := client.Do(ctx, "module.cmd", "key")
+			Expect(do.Err()).To(Equal(redis.Nil))
+		})
+
+		It("regexp", func() {
+			ExpectCmd(clientMock.Regexp(), func(c redis.Cmdable) *redis.StatusCmd {
+				return c.Set(ctx, "key", `^[a-z]+$`, 0)
+			}).SetVal("OK")
+
+			set := client.Set(ctx, "key", "value", 0)
+			Expect(set.Err()).NotTo(HaveOccurred())
+			Expect(set.Val()).To(Equal("OK"))
+		})
+
 	})
 
 	Describe("work error", func() {
@@ -392,5 +460,21 @@ var _ = Describe("Client", func() {
 			Expect(get.Val()).To(Equal(""))
 		})
 
+		It("do matched by typed command", func() {
+			clientMock.ExpectDo("get", "key").SetVal("value")
+
+			get := client.Get(ctx, "key")
+			Expect(get.Err()).NotTo(HaveOccurred())
+			Expect(get.Val()).To(Equal("value"))
+		})
+
+		It("do value type mismatch", func() {
+			clientMock.ExpectDo("incr", "key").SetVal(1)
+
+			incr := client.Incr(ctx, "key")
+			Expect(incr.Err()).To(HaveOccurred())
+			Expect(incr.Val()).To(Equal(int64(0)))
+		})
+
 	})
 })
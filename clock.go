@@ -0,0 +1,128 @@
+package redismock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the time of the mock, used by the fake store for the key expiry
+// and by the TTL expectations set with SetValExpireAt.
+// It follows the wall clock until it is moved with Advance or Set.
+type Clock struct {
+	mu     sync.Mutex
+	offset time.Duration
+	frozen time.Time
+	skew   time.Duration
+
+	// watchers run when the clock moves, the fake expires the keys
+	watchers []func()
+	// moved is closed when the clock moves, the blocked commands check their timeout
+	moved chan struct{}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.frozen.IsZero() {
+		return c.frozen
+	}
+	return time.Now().Add(c.offset)
+}
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	if !c.frozen.IsZero() {
+		c.frozen = c.frozen.Add(d)
+	} else {
+		c.offset += d
+	}
+	c.mu.Unlock()
+	c.notify()
+}
+
+// Set stops the clock at t, it only moves with Advance afterwards.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	c.frozen = t
+	c.mu.Unlock()
+	c.notify()
+}
+
+func (c *Clock) watch(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watchers = append(c.watchers, fn)
+}
+
+// changed returns a channel closed when the clock moves.
+func (c *Clock) changed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.moved == nil {
+		c.moved = make(chan struct{})
+	}
+	return c.moved
+}
+
+func (c *Clock) notify() {
+	c.mu.Lock()
+	watchers := append([]func(){}, c.watchers...)
+	if c.moved != nil {
+		close(c.moved)
+		c.moved = nil
+	}
+	c.mu.Unlock()
+
+	for _, fn := range watchers {
+		fn()
+	}
+}
+
+// SetSkew sets the difference tolerated between the timestamp of an expected
+// EXPIREAT/PEXPIREAT and the one of the call, the default is an exact match.
+func (c *Clock) SetSkew(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.skew = d
+}
+
+func (c *Clock) getSkew() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.skew
+}
+
+// matchSkew compares the timestamps of EXPIREAT and PEXPIREAT with the skew of the clock.
+func (c *Clock) matchSkew(cmd string, i int, expect, actual interface{}) bool {
+	var unit time.Duration
+	switch cmd {
+	case "expireat":
+		unit = time.Second
+	case "pexpireat":
+		unit = time.Millisecond
+	default:
+		return false
+	}
+
+	skew := c.getSkew()
+	if i != 2 || skew <= 0 {
+		return false
+	}
+	e, ok1 := expect.(int64)
+	a, ok2 := actual.(int64)
+	if !ok1 || !ok2 {
+		return false
+	}
+	diff := time.Duration(e-a) * unit
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= skew
+}
@@ -0,0 +1,76 @@
+package redismock
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Clock", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+		clock      *Clock
+		now        = time.Unix(1700000000, 0)
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientFakeMock()
+		clock = clientMock.Clock()
+		clock.Set(now)
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("advance", func() {
+		Expect(clock.Now()).To(Equal(now))
+		clock.Advance(time.Minute)
+		Expect(clock.Now()).To(Equal(now.Add(time.Minute)))
+
+		var wall Clock
+		wall.Advance(time.Hour)
+		Expect(wall.Now()).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+	})
+
+	It("ttl expectation", func() {
+		clientMock.ExpectTTL("key").SetValExpireAt(now.Add(time.Minute))
+		clientMock.ExpectPTTL("key").SetValExpireAt(now.Add(time.Minute))
+		clientMock.ExpectTTL("key").SetValExpireAt(now.Add(time.Minute))
+		clientMock.ExpectExpireTime("key").SetValExpireAt(now.Add(time.Minute))
+
+		Expect(client.TTL(ctx, "key").Val()).To(Equal(time.Minute))
+		clock.Advance(20 * time.Second)
+		Expect(client.PTTL(ctx, "key").Val()).To(Equal(40 * time.Second))
+		clock.Advance(time.Minute)
+		Expect(client.TTL(ctx, "key").Val()).To(Equal(time.Duration(-2)))
+		Expect(client.ExpireTime(ctx, "key").Val()).To(Equal(time.Duration(now.Unix()+60) * time.Second))
+	})
+
+	It("expire at skew", func() {
+		db, mock := NewClientMock()
+		mock.ExpectExpireAt("key", now.Add(time.Hour)).SetVal(true)
+		Expect(db.ExpireAt(ctx, "key", now.Add(time.Hour+time.Second)).Err()).To(HaveOccurred())
+		Expect(db.Close()).NotTo(HaveOccurred())
+
+		clock.SetSkew(2 * time.Second)
+		clientMock.ExpectExpireAt("key", now.Add(time.Hour)).SetVal(true)
+		clientMock.ExpectPExpireAt("key", now.Add(time.Hour)).SetVal(true)
+		Expect(client.ExpireAt(ctx, "key", now.Add(time.Hour+time.Second)).Val()).To(BeTrue())
+		Expect(client.PExpireAt(ctx, "key", now.Add(time.Hour-1500*time.Millisecond)).Val()).To(BeTrue())
+	})
+
+	It("fake expiry", func() {
+		Expect(client.Set(ctx, "key", "value", time.Minute).Err()).NotTo(HaveOccurred())
+		clock.Advance(30 * time.Second)
+		Expect(client.TTL(ctx, "key").Val()).To(Equal(30 * time.Second))
+		clock.Advance(30 * time.Second)
+		Expect(client.Get(ctx, "key").Err()).To(Equal(redis.Nil))
+
+		Expect(client.ExpireAt(ctx, "none", now).Val()).To(BeFalse())
+	})
+})
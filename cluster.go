@@ -0,0 +1,407 @@
+package redismock
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// clusterSlots is the number of hash slots of Redis Cluster.
+const clusterSlots = 16384
+
+// SlotRange is a range of hash slots, both ends included.
+type SlotRange struct {
+	Start, End int
+}
+
+// ClusterNodeSpec describes a node of a simulated cluster, a master serving slots or a replica of a master.
+type ClusterNodeSpec struct {
+	// Name identifies the node in the expectations and the methods of the Cluster, such as "master-1".
+	Name      string
+	Slots     []SlotRange
+	ReplicaOf string
+}
+
+// ClusterStats counts the cluster traffic of the nodes.
+type ClusterStats struct {
+	// Moved and Ask are the redirections replied by the nodes.
+	Moved, Ask int
+	// SlotsRequests is the number of CLUSTER SLOTS received, the clients refresh their slot map with it.
+	SlotsRequests int
+}
+
+// Cluster is a simulated Redis Cluster. Every node is a Server answering the commands with the
+// expectations of one ClusterClientMock, and the nodes reply CLUSTER SLOTS, COMMAND and the MOVED and ASK
+// redirections of the slot map, so a ClusterClient discovers the topology and follows the redirections.
+type Cluster struct {
+	m *mock
+
+	mu        sync.Mutex
+	nodes     []*clusterNode
+	owners    [clusterSlots]*clusterNode
+	importing map[int]*clusterNode
+	stats     ClusterStats
+}
+
+type clusterNode struct {
+	cluster *Cluster
+	name    string
+	id      string
+	master  *clusterNode
+	srv     *Server
+}
+
+// NewCluster starts the nodes of a cluster listening on free local TCP ports.
+func NewCluster(nodes ...ClusterNodeSpec) (*Cluster, error) {
+	c := &Cluster{
+		m:         newMock(redisCluster),
+		importing: make(map[int]*clusterNode),
+	}
+	c.m.nodes = true
+
+	byName := make(map[string]*clusterNode)
+	for _, spec := range nodes {
+		if spec.Name == "" || byName[spec.Name] != nil {
+			return nil, fmt.Errorf("redismock: the cluster node name %q is empty or duplicated", spec.Name)
+		}
+		sum := sha1.Sum([]byte(spec.Name))
+		n := &clusterNode{cluster: c, name: spec.Name, id: hex.EncodeToString(sum[:])}
+		byName[spec.Name] = n
+		c.nodes = append(c.nodes, n)
+	}
+
+	for i, spec := range nodes {
+		n := c.nodes[i]
+		if spec.ReplicaOf != "" {
+			if n.master = byName[spec.ReplicaOf]; n.master == nil {
+				return nil, fmt.Errorf("redismock: the master %q of %q is not a node", spec.ReplicaOf, spec.Name)
+			}
+			if len(spec.Slots) > 0 {
+				return nil, fmt.Errorf("redismock: the replica %q serves slots", spec.Name)
+			}
+		}
+		for _, r := range spec.Slots {
+			if err := r.check(); err != nil {
+				return nil, err
+			}
+			for slot := r.Start; slot <= r.End; slot++ {
+				if c.owners[slot] != nil {
+					return nil, fmt.Errorf("redismock: the slot %d is served by %q and %q", slot, c.owners[slot].name, spec.Name)
+				}
+				c.owners[slot] = n
+			}
+		}
+	}
+	for _, n := range c.nodes {
+		if n.master != nil && n.master.master != nil {
+			return nil, fmt.Errorf("redismock: the master %q of %q is a replica", n.master.name, n.name)
+		}
+	}
+
+	for _, n := range c.nodes {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+		n.srv = newServer(c.m, ln, n)
+	}
+	return c, nil
+}
+
+func (r SlotRange) check() error {
+	if r.Start < 0 || r.End >= clusterSlots || r.Start > r.End {
+		return fmt.Errorf("redismock: invalid slot range %d-%d", r.Start, r.End)
+	}
+	return nil
+}
+
+// Mock returns the mock of the expectations of the nodes.
+func (c *Cluster) Mock() ClusterClientMock {
+	return c.m
+}
+
+// Options returns the options of a ClusterClient discovering the cluster from its nodes.
+func (c *Cluster) Options() *redis.ClusterOptions {
+	addrs := make([]string, 0, len(c.nodes))
+	for _, n := range c.nodes {
+		addrs = append(addrs, n.srv.Addr())
+	}
+	return &redis.ClusterOptions{Addrs: addrs}
+}
+
+// Masters returns the names of the masters, in the order of NewCluster.
+func (c *Cluster) Masters() []string {
+	return c.names(func(n *clusterNode) bool { return n.master == nil })
+}
+
+// Replicas returns the names of the replicas of a master.
+func (c *Cluster) Replicas(master string) []string {
+	m := c.node(master)
+	return c.names(func(n *clusterNode) bool { return n.master == m })
+}
+
+func (c *Cluster) names(fn func(n *clusterNode) bool) []string {
+	var names []string
+	for _, n := range c.nodes {
+		if fn(n) {
+			names = append(names, n.name)
+		}
+	}
+	return names
+}
+
+// Addr returns the address of a node, it panics if there is no node with the name.
+func (c *Cluster) Addr(name string) string {
+	return c.node(name).srv.Addr()
+}
+
+func (c *Cluster) node(name string) *clusterNode {
+	for _, n := range c.nodes {
+		if n.name == name {
+			return n
+		}
+	}
+	panic(fmt.Sprintf("redismock: no cluster node %q", name))
+}
+
+// MigrateSlots starts migrating the slots to the master to: their owner replies ASK to the commands of
+// their keys, considered migrated, and the master accepts the commands following ASKING.
+// CLUSTER SLOTS is not changed until MoveSlots.
+func (c *Cluster) MigrateSlots(start, end int, to string) {
+	n := c.master(start, end, to)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for slot := start; slot <= end; slot++ {
+		c.importing[slot] = n
+	}
+}
+
+// MoveSlots assigns the slots to the master to, ending their migration. The previous owners
+// reply MOVED to the commands of their keys and CLUSTER SLOTS returns the new slot map.
+func (c *Cluster) MoveSlots(start, end int, to string) {
+	n := c.master(start, end, to)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for slot := start; slot <= end; slot++ {
+		c.owners[slot] = n
+		delete(c.importing, slot)
+	}
+}
+
+func (c *Cluster) master(start, end int, name string) *clusterNode {
+	if err := (SlotRange{start, end}).check(); err != nil {
+		panic(err)
+	}
+	n := c.node(name)
+	if n.master != nil {
+		panic(fmt.Sprintf("redismock: the cluster node %q is a replica", name))
+	}
+	return n
+}
+
+// Stats returns the redirections and the CLUSTER SLOTS requests of the nodes.
+func (c *Cluster) Stats() ClusterStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Close stops the nodes.
+func (c *Cluster) Close() error {
+	var err error
+	for _, n := range c.nodes {
+		if n.srv == nil {
+			continue
+		}
+		if e := n.srv.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// slotsReply is the reply of CLUSTER SLOTS, the contiguous slots of a master with its replicas.
+func (c *Cluster) slotsReply() []interface{} {
+	reply := []interface{}{}
+	for start := 0; start < clusterSlots; {
+		owner, end := c.owners[start], start
+		for end+1 < clusterSlots && c.owners[end+1] == owner {
+			end++
+		}
+		if owner != nil {
+			entry := []interface{}{int64(start), int64(end), owner.endpoint()}
+			for _, n := range c.nodes {
+				if n.master == owner {
+					entry = append(entry, n.endpoint())
+				}
+			}
+			reply = append(reply, entry)
+		}
+		start = end + 1
+	}
+	return reply
+}
+
+func (n *clusterNode) endpoint() []interface{} {
+	host, port, _ := net.SplitHostPort(n.srv.Addr())
+	p, _ := strconv.ParseInt(port, 10, 64)
+	return []interface{}{host, p, n.id}
+}
+
+// handle answers the cluster commands and the redirections of the node, ok is false
+// for the commands the node serves with the mock.
+func (n *clusterNode) handle(conn *respConn, args []string) (reply interface{}, ok bool) {
+	c := n.cluster
+	name := strings.ToLower(args[0])
+	switch {
+	case name == "cluster" && len(args) == 2 && strings.EqualFold(args[1], "slots"):
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.stats.SlotsRequests++
+		return c.slotsReply(), true
+	case name == "cluster" && len(args) == 3 && strings.EqualFold(args[1], "keyslot"):
+		return int64(keySlot(args[2])), true
+	case name == "cluster" && len(args) == 2 && strings.EqualFold(args[1], "myid"):
+		return n.id, true
+	case name == "command" && len(args) == 1:
+		return commandReply(), true
+	case name == "asking":
+		conn.asking = true
+		return respStatus("OK"), true
+	case name == "readonly" || name == "readwrite":
+		conn.readonly = name == "readonly"
+		return respStatus("OK"), true
+	case name == "ping" && len(args) <= 2 && !conn.multi && !conn.subscribed():
+		// the clients measure the latency of the nodes
+		if len(args) == 2 {
+			return args[1], true
+		}
+		return respStatus("PONG"), true
+	}
+
+	// ASKING is valid for the next command, or the commands of the transaction it precedes
+	asking := conn.asking
+	if name == "exec" || name == "discard" || !conn.multi && name != "multi" {
+		conn.asking = false
+	}
+
+	keys := commandKeys(args)
+	slot, err := keysSlot(keys)
+	if err != nil {
+		if c.m.crossSlot {
+			return err, true
+		}
+		slot = keySlot(keys[0])
+	}
+	if slot < 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	owner, importing := c.owners[slot], c.importing[slot]
+	switch {
+	case owner == nil:
+		return respError("CLUSTERDOWN Hash slot not served"), true
+	case n.master != nil && n.master == owner && conn.readonly && readonlyCommand(name):
+		return nil, false
+	case owner == n && importing != nil:
+		c.stats.Ask++
+		return respError(fmt.Sprintf("ASK %d %s", slot, importing.srv.Addr())), true
+	case owner == n, importing == n && asking:
+		return nil, false
+	}
+	c.stats.Moved++
+	return respError(fmt.Sprintf("MOVED %d %s", slot, owner.srv.Addr())), true
+}
+
+// is tells whether the node is the one of an expectation scoped by Node, by name, address or role.
+func (n *clusterNode) is(scope string) bool {
+	switch scope {
+	case n.name, n.srv.Addr():
+		return true
+	case "master":
+		return n.master == nil
+	case "replica":
+		return n.master != nil
+	}
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+// keysSlot returns the slot of the keys, -1 without keys, or an error if the keys are in several slots.
+func keysSlot(keys []string) (int, error) {
+	slot := -1
+	for _, key := range keys {
+		if s := keySlot(key); slot < 0 {
+			slot = s
+		} else if s != slot {
+			return 0, respError(msgCrossSlot)
+		}
+	}
+	return slot, nil
+}
+
+// CheckCrossSlot enables or disables the CROSSSLOT error of the commands with keys in several hash slots,
+// as Redis Cluster replies it. It is enabled for the cluster mocks, and the transactions of a Server
+// fail at EXEC with it when their commands are in several slots.
+func (m *mock) CheckCrossSlot(on bool) {
+	if m.parent != nil {
+		m.parent.CheckCrossSlot(on)
+		return
+	}
+	m.crossSlot = on
+}
+
+// keySlot returns the hash slot of a key, the CRC16 of its {hashtag} if it has one.
+func keySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % clusterSlots
+}
+
+// crc16 is the CRC16-CCITT (XMODEM) of Redis Cluster.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+//------------------------------------------------------------------------------
+
+// Node scopes the expectations to a node of a Cluster, they only match the commands the node receives.
+// The node is a name, an address, or the role "master" or "replica" for any node of the role.
+// In strict order, the expectations of a node are ordered apart from the other nodes.
+// It panics if the mock is not the one of a Cluster, see Cluster.Mock, as the commands of
+// a ClusterClient of NewClusterMock are received by no node.
+func (m *mock) Node(name string) *mock {
+	if !m.nodes {
+		panic("redismock: Node scopes the expectations to the nodes of a Cluster, see NewCluster")
+	}
+	clone := m.clone()
+	clone.expectNode = name
+
+	return clone
+}
@@ -3459,6 +3459,9 @@ var _ = Describe("Commands", func() {
 		BeforeEach(func() {
 			client, clientMock = NewClusterMock()
 			clientType = redisCluster
+
+			// the commands are called with keys in several slots
+			clientMock.(ClusterClientMock).CheckCrossSlot(false)
 		})
 
 		AfterEach(func() {
@@ -0,0 +1,275 @@
+package redismock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+// conformanceVal converts the value given to SetVal into the value returned by Val,
+// for the Expected types whose SetVal does not take the Cmd value as is.
+var conformanceVal = map[string]func(in []reflect.Value) []interface{}{
+	"ExpectedCommandsInfo": func(in []reflect.Value) []interface{} {
+		val := make(map[string]*redis.CommandInfo)
+		for _, v := range in[0].Interface().([]*redis.CommandInfo) {
+			val[v.Name] = v
+		}
+		return []interface{}{val}
+	},
+	"ExpectedStringStructMap": func(in []reflect.Value) []interface{} {
+		val := make(map[string]struct{})
+		for _, v := range in[0].Interface().([]string) {
+			val[v] = struct{}{}
+		}
+		return []interface{}{val}
+	},
+}
+
+// conformanceArgs adjusts the generated arguments of the commands that reject them on the client side.
+var conformanceArgs = map[string]func(args []reflect.Value){
+	"GeoRadius":         conformanceGeoRadius,
+	"GeoRadiusByMember": conformanceGeoRadius,
+}
+
+func conformanceGeoRadius(args []reflect.Value) {
+	q := args[len(args)-1].Interface().(*redis.GeoRadiusQuery)
+	q.Store, q.StoreDist = "", ""
+}
+
+// conformanceSkip are the commands that cannot be sent through the mock.
+var conformanceSkip = map[string]string{
+	"Quit":           "not implemented by go-redis",
+	"ClientTracking": "no go-redis method, sent with Do",
+	"Script":         "matched by Eval or EvalSha",
+	"ScanAll":        "matched by the calls of Scan",
+	"SScanAll":       "matched by the calls of SScan",
+	"HScanAll":       "matched by the calls of HScan",
+	"ZScanAll":       "matched by the calls of ZScan",
+}
+
+// conformanceReplyErr are the commands where go-redis turns the reply into an error.
+var conformanceReplyErr = map[string]bool{
+	"Shutdown":       true,
+	"ShutdownSave":   true,
+	"ShutdownNoSave": true,
+}
+
+// conformanceFill returns a non-zero value of type t, exported struct fields are filled recursively.
+func conformanceFill(t reflect.Type, depth int) reflect.Value {
+	v := reflect.New(t).Elem()
+	if depth > 5 {
+		return v
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return reflect.ValueOf(time.Unix(1700000000, 0))
+	case reflect.TypeOf(time.Duration(0)):
+		return reflect.ValueOf(3 * time.Second)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(7)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(7)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Interface:
+		v.Set(reflect.ValueOf("value"))
+	case reflect.Ptr:
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(conformanceFill(t.Elem(), depth+1))
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(t, 1, 1))
+		v.Index(0).Set(conformanceFill(t.Elem(), depth+1))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(t))
+		v.SetMapIndex(conformanceFill(t.Key(), depth+1), conformanceFill(t.Elem(), depth+1))
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				v.Field(i).Set(conformanceFill(t.Field(i).Type, depth+1))
+			}
+		}
+	}
+	return v
+}
+
+type conformanceCase struct {
+	name     string
+	expect   reflect.Method
+	args     []reflect.Value
+	variadic bool
+}
+
+// conformanceCases lists every Expect* method of baseMock with the arguments to call it.
+func conformanceCases() []conformanceCase {
+	var cases []conformanceCase
+
+	typ := reflect.TypeOf((*baseMock)(nil)).Elem()
+	for i := 0; i < typ.NumMethod(); i++ {
+		method := typ.Method(i)
+		if !strings.HasPrefix(method.Name, "Expect") || method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.Ptr {
+			continue
+		}
+
+		c := conformanceCase{
+			name:     strings.TrimPrefix(method.Name, "Expect"),
+			expect:   method,
+			variadic: method.Type.IsVariadic(),
+		}
+		for j := 0; j < method.Type.NumIn(); j++ {
+			c.args = append(c.args, conformanceFill(method.Type.In(j), 0))
+		}
+		if fn, ok := conformanceArgs[c.name]; ok {
+			fn(c.args)
+		}
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+func (c conformanceCase) call(fn reflect.Value, args []reflect.Value) []reflect.Value {
+	if c.variadic {
+		return fn.CallSlice(args)
+	}
+	return fn.Call(args)
+}
+
+// run calls the Expect* method on the mock and returns the Expected value,
+// with a function sending the command of the same name through the client.
+func (c conformanceCase) run(m baseMock, client interface{}) (reflect.Value, func() redis.Cmder) {
+	m.ClearExpect()
+	e := c.call(reflect.ValueOf(m).MethodByName(c.expect.Name), c.args)[0]
+
+	fn := reflect.ValueOf(client).MethodByName(c.name)
+	Expect(fn.IsValid()).To(BeTrue(), "client has no method %s", c.name)
+
+	args := append([]reflect.Value{reflect.ValueOf(context.Context(ctx))}, c.args...)
+	return e, func() redis.Cmder {
+		return c.call(fn, args)[0].Interface().(redis.Cmder)
+	}
+}
+
+func conformanceTest(m baseMock, client interface{}, c conformanceCase) {
+	setErr := errors.New("conformance error")
+
+	// error
+	e, cmd := c.run(m, client)
+	e.MethodByName("SetErr").Call([]reflect.Value{reflect.ValueOf(setErr)})
+	Expect(cmd().Err()).To(Equal(setErr))
+	Expect(m.ExpectationsWereMet()).NotTo(HaveOccurred())
+
+	// redis.Nil
+	e, cmd = c.run(m, client)
+	e.MethodByName("RedisNil").Call(nil)
+	Expect(cmd().Err()).To(Equal(redis.Nil))
+	Expect(m.ExpectationsWereMet()).NotTo(HaveOccurred())
+
+	// value
+	e, cmd = c.run(m, client)
+	setVal := e.MethodByName("SetVal")
+	Expect(setVal.IsValid()).To(BeTrue(), "%s has no SetVal", e.Type())
+
+	in := make([]reflect.Value, setVal.Type().NumIn())
+	for i := range in {
+		in[i] = conformanceFill(setVal.Type().In(i), 0)
+	}
+	setVal.Call(in)
+
+	want := make([]interface{}, len(in))
+	for i, v := range in {
+		want[i] = v.Interface()
+	}
+	if fn, ok := conformanceVal[e.Elem().Type().Name()]; ok {
+		want = fn(in)
+	}
+
+	res := cmd()
+	if conformanceReplyErr[c.name] {
+		Expect(res.Err()).To(Equal(errors.New(want[0].(string))))
+		Expect(m.ExpectationsWereMet()).NotTo(HaveOccurred())
+		return
+	}
+	Expect(res.Err()).NotTo(HaveOccurred())
+
+	out := reflect.ValueOf(res).MethodByName("Val").Call(nil)
+	Expect(out).To(HaveLen(len(want)))
+	for i := range out {
+		Expect(out[i].Interface()).To(Equal(want[i]), fmt.Sprintf("%s value #%d", c.name, i))
+	}
+	Expect(m.ExpectationsWereMet()).NotTo(HaveOccurred())
+}
+
+var _ = Describe("Conformance", func() {
+	var (
+		clientMock baseMock
+		client     interface{}
+	)
+
+	conformance := func() {
+		for _, c := range conformanceCases() {
+			c := c
+			if reason, ok := conformanceSkip[c.name]; ok {
+				PIt(c.name + ": " + reason)
+				continue
+			}
+			It(c.name, func() {
+				conformanceTest(clientMock, client, c)
+			})
+		}
+	}
+
+	Describe("client", func() {
+		BeforeEach(func() {
+			client, clientMock = NewClientMock()
+		})
+
+		AfterEach(func() {
+			Expect(client.(*redis.Client).Close()).NotTo(HaveOccurred())
+		})
+
+		conformance()
+	})
+
+	for _, protocol := range []int{2, 3} {
+		protocol := protocol
+
+		Describe(fmt.Sprintf("client resp%d", protocol), func() {
+			BeforeEach(func() {
+				client, clientMock = NewClientMock()
+				clientMock.SetProtocol(protocol)
+			})
+
+			AfterEach(func() {
+				Expect(client.(*redis.Client).Close()).NotTo(HaveOccurred())
+			})
+
+			conformance()
+		})
+	}
+
+	Describe("cluster", func() {
+		BeforeEach(func() {
+			client, clientMock = NewClusterMock()
+		})
+
+		AfterEach(func() {
+			Expect(client.(*redis.ClusterClient).Close()).NotTo(HaveOccurred())
+		})
+
+		conformance()
+	})
+})
@@ -0,0 +1,129 @@
+package redismock
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("CrossSlot", func() {
+	var (
+		client      *redis.ClusterClient
+		clusterMock ClusterClientMock
+	)
+
+	BeforeEach(func() {
+		client, clusterMock = NewClusterMock()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("commands", func() {
+		clusterMock.ExpectMGet("a", "b").SetVal([]interface{}{"1", "2"})
+		clusterMock.ExpectSUnionStore("dest", "a", "b").SetVal(2)
+		clusterMock.ExpectRename("a", "b").SetVal("OK")
+		clusterMock.ExpectEval("return 1", []string{"a", "b"}).SetVal(int64(1))
+
+		err := client.MGet(ctx, "a", "b").Err()
+		Expect(err).To(MatchError(msgCrossSlot))
+		Expect(redis.HasErrorPrefix(err, "CROSSSLOT")).To(BeTrue())
+		Expect(client.SUnionStore(ctx, "dest", "a", "b").Err()).To(MatchError(msgCrossSlot))
+		Expect(client.Rename(ctx, "a", "b").Err()).To(MatchError(msgCrossSlot))
+		Expect(client.Eval(ctx, "return 1", []string{"a", "b"}).Err()).To(MatchError(msgCrossSlot))
+
+		// the expectations are not met, as Redis never received the commands
+		Expect(clusterMock.ExpectationsWereMet()).To(HaveOccurred())
+	})
+
+	It("store", func() {
+		for _, c := range []struct {
+			args []string
+			keys []string
+		}{
+			{[]string{"zrangestore", "dst", "src", "0", "-1"}, []string{"dst", "src"}},
+			{[]string{"sort", "list", "by", "store", "get", "#", "limit", "0", "10", "store", "dst"}, []string{"list", "dst"}},
+			{[]string{"sort", "list", "alpha"}, []string{"list"}},
+			{[]string{"sort_ro", "list", "alpha"}, []string{"list"}},
+			{[]string{"georadius", "geo", "15", "37", "200", "km", "count", "5", "store", "dst"}, []string{"geo", "dst"}},
+			{[]string{"georadiusbymember", "geo", "store", "200", "km", "storedist", "dst"}, []string{"geo", "dst"}},
+		} {
+			Expect(commandKeys(c.args)).To(Equal(c.keys), c.args[0])
+		}
+
+		clusterMock.ExpectZRangeStore("dst", redis.ZRangeArgs{Key: "src", Start: 0, Stop: -1}).SetVal(1)
+		clusterMock.ExpectSortStore("list", "dst", &redis.Sort{}).SetVal(1)
+		clusterMock.ExpectGeoRadiusStore("geo", 15, 37, &redis.GeoRadiusQuery{Radius: 200, Unit: "km", Store: "dst"}).SetVal(1)
+		clusterMock.ExpectGeoRadiusByMemberStore("geo", "a", &redis.GeoRadiusQuery{Radius: 200, Unit: "km", StoreDist: "dst"}).SetVal(1)
+
+		Expect(client.ZRangeStore(ctx, "dst", redis.ZRangeArgs{Key: "src", Start: 0, Stop: -1}).Err()).To(MatchError(msgCrossSlot))
+		Expect(client.SortStore(ctx, "list", "dst", &redis.Sort{}).Err()).To(MatchError(msgCrossSlot))
+		Expect(client.GeoRadiusStore(ctx, "geo", 15, 37, &redis.GeoRadiusQuery{Radius: 200, Unit: "km", Store: "dst"}).Err()).To(
+			MatchError(msgCrossSlot))
+		Expect(client.GeoRadiusByMemberStore(ctx, "geo", "a", &redis.GeoRadiusQuery{Radius: 200, Unit: "km", StoreDist: "dst"}).Err()).To(
+			MatchError(msgCrossSlot))
+		Expect(clusterMock.ExpectationsWereMet()).To(HaveOccurred())
+
+		clusterMock.ClearExpect()
+		clusterMock.ExpectSortStore("{list}", "{list}:sorted", &redis.Sort{}).SetVal(1)
+		Expect(client.SortStore(ctx, "{list}", "{list}:sorted", &redis.Sort{}).Val()).To(Equal(int64(1)))
+		Expect(clusterMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("hashtag", func() {
+		clusterMock.ExpectMGet("{user:1}:name", "{user:1}:mail").SetVal([]interface{}{"alice", "a@b.c"})
+		clusterMock.ExpectRename("{user:1}:name", "{user:1}:old").SetVal("OK")
+		clusterMock.ExpectMSet("{a}1", "1", "{a}2", "2").SetVal("OK")
+
+		Expect(client.MGet(ctx, "{user:1}:name", "{user:1}:mail").Val()).To(Equal([]interface{}{"alice", "a@b.c"}))
+		Expect(client.Rename(ctx, "{user:1}:name", "{user:1}:old").Val()).To(Equal("OK"))
+		Expect(client.MSet(ctx, "{a}1", "1", "{a}2", "2").Val()).To(Equal("OK"))
+		Expect(clusterMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("disabled", func() {
+		clusterMock.CheckCrossSlot(false)
+		clusterMock.ExpectMGet("a", "b").SetVal([]interface{}{"1", "2"})
+
+		Expect(client.MGet(ctx, "a", "b").Val()).To(Equal([]interface{}{"1", "2"}))
+		Expect(clusterMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("transaction", func() {
+		srv, err := NewServer(clusterMock)
+		Expect(err).NotTo(HaveOccurred())
+		defer srv.Close()
+		conn := dialRaw(srv)
+		defer conn.Close()
+
+		clusterMock.ExpectDo("multi").SetVal("OK")
+		Expect(conn.do("multi")).To(Equal("OK"))
+		Expect(conn.do("set", "a", "1")).To(Equal("QUEUED"))
+		Expect(conn.do("set", "b", "2")).To(Equal("QUEUED"))
+		Expect(conn.do("exec")).To(MatchError(msgCrossSlot))
+
+		clusterMock.ExpectDo("multi").SetVal("OK")
+		Expect(conn.do("multi")).To(Equal("OK"))
+		Expect(conn.do("mget", "a", "b")).To(MatchError(msgCrossSlot))
+		Expect(conn.do("exec")).To(MatchError(HavePrefix("EXECABORT")))
+		Expect(clusterMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("cluster", func() {
+		cluster, err := NewCluster(
+			ClusterNodeSpec{Name: "master-1", Slots: []SlotRange{{0, 8191}}},
+			ClusterNodeSpec{Name: "master-2", Slots: []SlotRange{{8192, 16383}}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer cluster.Close()
+
+		// CROSSSLOT is replied before the redirection
+		conn := dialRaw(cluster.node("master-1").srv)
+		defer conn.Close()
+		Expect(conn.do("mget", "key", "user")).To(MatchError(msgCrossSlot))
+
+		cluster.Mock().CheckCrossSlot(false)
+		Expect(conn.do("mget", "key", "user")).To(MatchError(HavePrefix("MOVED 12539")))
+	})
+})
@@ -0,0 +1,89 @@
+package redismock
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The messages of the server errors, as Redis replies them.
+const (
+	msgLoading     = "LOADING Redis is loading the dataset in memory"
+	msgReadOnly    = "READONLY You can't write against a read only replica."
+	msgBusy        = "BUSY Redis is busy running a script. You can only call SCRIPT KILL or SHUTDOWN NOSCRIPT."
+	msgNoScript    = "NOSCRIPT No matching script. Please use EVAL."
+	msgWrongType   = "WRONGTYPE Operation against a key holding the wrong kind of value"
+	msgOOM         = "OOM command not allowed when used memory > 'maxmemory'."
+	msgClusterDown = "CLUSTERDOWN The cluster is down"
+	msgTryAgain    = "TRYAGAIN Multiple keys request during rehashing of slot"
+	msgCrossSlot   = "CROSSSLOT Keys in request don't hash to the same slot"
+)
+
+// ServerError returns the error of a reply of Redis with the message, such as "ERR unknown command".
+// go-redis handles it as a reply of a server: it is a redis.Error, and the prefix
+// decides whether the client retries the command or follows a redirection.
+func ServerError(msg string) error {
+	// the reply is a single line
+	msg = strings.NewReplacer("\r", " ", "\n", " ").Replace(msg)
+	_, replyErr, err := decodeRESP([]byte("-" + msg + "\r\n"))
+	if err != nil {
+		panic(err)
+	}
+	return replyErr
+}
+
+// MovedError returns the MOVED redirection of a slot to the node at addr,
+// a ClusterClient updates its slots and follows it up to MaxRedirects times.
+func MovedError(slot int, addr string) error {
+	return ServerError(fmt.Sprintf("MOVED %d %s", slot, addr))
+}
+
+// AskError returns the ASK redirection of a slot being migrated to the node at addr,
+// a ClusterClient sends ASKING then the command to the node.
+func AskError(slot int, addr string) error {
+	return ServerError(fmt.Sprintf("ASK %d %s", slot, addr))
+}
+
+// LoadingError returns the error of a server loading its dataset, the clients retry the command.
+func LoadingError() error {
+	return ServerError(msgLoading)
+}
+
+// ReadOnlyError returns the error of a write sent to a replica, the clients retry the command.
+func ReadOnlyError() error {
+	return ServerError(msgReadOnly)
+}
+
+// ClusterDownError returns the error of a cluster not serving the slot, the clients retry the command.
+func ClusterDownError() error {
+	return ServerError(msgClusterDown)
+}
+
+// TryAgainError returns the error of a multi-key command during resharding, the clients retry the command.
+func TryAgainError() error {
+	return ServerError(msgTryAgain)
+}
+
+// CrossSlotError returns the error of a Redis Cluster command with keys in several hash slots.
+func CrossSlotError() error {
+	return ServerError(msgCrossSlot)
+}
+
+// BusyError returns the error of a server running a long script.
+func BusyError() error {
+	return ServerError(msgBusy)
+}
+
+// NoScriptError returns the error of EVALSHA of a script not loaded, redis.Script falls back to EVAL.
+func NoScriptError() error {
+	return ServerError(msgNoScript)
+}
+
+// WrongTypeError returns the error of a command against a key holding another type.
+func WrongTypeError() error {
+	return ServerError(msgWrongType)
+}
+
+// OOMError returns the error of a write over maxmemory.
+func OOMError() error {
+	return ServerError(msgOOM)
+}
@@ -0,0 +1,126 @@
+package redismock
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Errors", func() {
+	It("redis errors", func() {
+		client, clientMock := NewClientMock()
+		defer client.Close()
+
+		for _, c := range []struct {
+			err    error
+			prefix string
+			msg    string
+		}{
+			{MovedError(3999, "127.0.0.1:6381"), "MOVED", "MOVED 3999 127.0.0.1:6381"},
+			{AskError(3999, "127.0.0.1:6381"), "ASK", "ASK 3999 127.0.0.1:6381"},
+			{LoadingError(), "LOADING", "LOADING Redis is loading the dataset in memory"},
+			{ReadOnlyError(), "READONLY", "READONLY You can't write against a read only replica."},
+			{BusyError(), "BUSY", msgBusy},
+			{NoScriptError(), "NOSCRIPT", "NOSCRIPT No matching script. Please use EVAL."},
+			{WrongTypeError(), "WRONGTYPE", msgWrongType},
+			{OOMError(), "OOM", "OOM command not allowed when used memory > 'maxmemory'."},
+			{ClusterDownError(), "CLUSTERDOWN", msgClusterDown},
+			{TryAgainError(), "TRYAGAIN", msgTryAgain},
+			{CrossSlotError(), "CROSSSLOT", msgCrossSlot},
+			{ServerError("ERR custom\nfailure"), "custom", "ERR custom failure"},
+		} {
+			clientMock.ExpectGet("key").SetErr(c.err)
+			err := client.Get(ctx, "key").Err()
+			Expect(err).To(MatchError(c.msg))
+			Expect(err).To(BeAssignableToTypeOf(c.err))
+			_, ok := err.(redis.Error)
+			Expect(ok).To(BeTrue())
+			Expect(redis.HasErrorPrefix(err, c.prefix)).To(BeTrue())
+		}
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("noscript", func() {
+		client, clientMock := NewClientMock()
+		defer client.Close()
+
+		script := redis.NewScript("return 1")
+		clientMock.ExpectEvalSha(script.Hash(), []string{"key"}).SetErr(NoScriptError())
+		clientMock.ExpectEval("return 1", []string{"key"}).SetVal(int64(1))
+
+		Expect(script.Run(ctx, client, []string{"key"}).Val()).To(Equal(int64(1)))
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("retries", func() {
+		_, clientMock := NewClientMock()
+		srv, err := NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+		defer srv.Close()
+
+		opt := srv.Options()
+		opt.MaxRetries, opt.MinRetryBackoff, opt.MaxRetryBackoff = 2, time.Millisecond, time.Millisecond
+		client := redis.NewClient(opt)
+		defer client.Close()
+
+		clientMock.ExpectGet("key").SetErr(LoadingError())
+		clientMock.ExpectGet("key").SetErr(ReadOnlyError())
+		clientMock.ExpectGet("key").SetVal("value")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+
+		// not retried
+		clientMock.ExpectGet("key").SetErr(OOMError())
+		Expect(client.Get(ctx, "key").Err()).To(MatchError(msgOOM))
+
+		// over MaxRetries
+		for i := 0; i < 3; i++ {
+			clientMock.ExpectGet("key").SetErr(TryAgainError())
+		}
+		Expect(client.Get(ctx, "key").Err()).To(MatchError(msgTryAgain))
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("redirects", func() {
+		_, mock1 := NewClientMock()
+		_, mock2 := NewClientMock()
+		srv1, err := NewServer(mock1)
+		Expect(err).NotTo(HaveOccurred())
+		defer srv1.Close()
+		srv2, err := NewServer(mock2)
+		Expect(err).NotTo(HaveOccurred())
+		defer srv2.Close()
+
+		client := redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        []string{srv1.Addr()},
+			MaxRedirects: 1,
+			ClusterSlots: func(context.Context) ([]redis.ClusterSlot, error) {
+				return []redis.ClusterSlot{{Start: 0, End: 16383, Nodes: []redis.ClusterNode{{Addr: srv1.Addr()}}}}, nil
+			},
+		})
+		defer client.Close()
+
+		// the command table, read by the client for the keys of the commands
+		mock1.ExpectDo("command").SetVal([]interface{}{
+			[]interface{}{"get", int64(2), []interface{}{"readonly", "fast"}, int64(1), int64(1), int64(1)},
+		})
+		mock1.ExpectGet("key").SetErr(AskError(12539, srv2.Addr()))
+		mock2.ExpectDo("asking").SetVal("OK")
+		mock2.ExpectGet("key").SetVal("migrating")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("migrating"))
+
+		mock1.ExpectGet("key").SetErr(MovedError(12539, srv2.Addr()))
+		mock2.ExpectGet("key").SetVal("moved")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("moved"))
+
+		// over MaxRedirects
+		mock1.ExpectGet("key").SetErr(MovedError(12539, srv2.Addr()))
+		mock2.ExpectGet("key").SetErr(MovedError(12539, srv1.Addr()))
+		Expect(client.Get(ctx, "key").Err()).To(MatchError(HavePrefix("MOVED")))
+
+		Expect(mock1.ExpectationsWereMet()).NotTo(HaveOccurred())
+		Expect(mock2.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+})
@@ -2,10 +2,10 @@ package redismock
 
 import (
 	"fmt"
+	"io"
 	"reflect"
 	"sync"
 	"time"
-	"unsafe"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -31,369 +31,62 @@ type baseMock interface {
 	// MatchExpectationsInOrder gives an option whether to match all expectations in the order they were set or not.
 	MatchExpectationsInOrder(b bool)
 
+	// Clock returns the clock of the mock, used for the key expiry of the fake
+	// and the TTL expectations set with SetValExpireAt.
+	Clock() *Clock
+
+	// SetProtocol selects RESP2 or RESP3 for the replies of the expectations, they are
+	// converted to the shapes the protocol produces. 0 returns the values as they are set.
+	SetProtocol(protocol int)
+
+	// SetNotifyKeyspaceEvents sets notify-keyspace-events as CONFIG SET does, the classes of
+	// the keyspace notifications published to the clients of a Server.
+	SetNotifyKeyspaceEvents(flags string) error
+
+	// NotifyKeyspaceEvent publishes the keyspace and keyevent notifications of an event of a key.
+	NotifyKeyspaceEvent(event, key string)
+
+	// History returns the commands processed by the mock in order, with their expectation and result.
+	History() []HistoryEntry
+
+	// Monitor writes the processed commands to w in the format of redis-cli monitor until stop is called.
+	Monitor(w io.Writer) (stop func())
+
+	// ExpectGoldenFile loads a golden file written by a Recorder as an ordered list of expectations.
+	ExpectGoldenFile(path string) error
+
+	// LoadExpectations reads expectations from YAML or JSON, a list of commands with matchers
+	// for the args, typed replies and call counts, and the ordering mode.
+	LoadExpectations(r io.Reader) error
+
+	// DumpExpectations writes the registered expectations in the format of LoadExpectations.
+	DumpExpectations(w io.Writer) error
+
 	ExpectDo(args ...interface{}) *ExpectedCmd
-	ExpectCommand() *ExpectedCommandsInfo
-	ExpectCommandList(filter *redis.FilterBy) *ExpectedStringSlice
-	ExpectCommandGetKeys(commands ...interface{}) *ExpectedStringSlice
-	ExpectCommandGetKeysAndFlags(commands ...interface{}) *ExpectedKeyFlags
-	ExpectClientGetName() *ExpectedString
-	ExpectEcho(message interface{}) *ExpectedString
-	ExpectPing() *ExpectedStatus
-	ExpectQuit() *ExpectedStatus
-	ExpectDel(keys ...string) *ExpectedInt
-	ExpectUnlink(keys ...string) *ExpectedInt
-	ExpectDump(key string) *ExpectedString
-	ExpectExists(keys ...string) *ExpectedInt
-	ExpectExpire(key string, expiration time.Duration) *ExpectedBool
-	ExpectExpireAt(key string, tm time.Time) *ExpectedBool
-	ExpectExpireTime(key string) *ExpectedDuration
-	ExpectExpireNX(key string, expiration time.Duration) *ExpectedBool
-	ExpectExpireXX(key string, expiration time.Duration) *ExpectedBool
-	ExpectExpireGT(key string, expiration time.Duration) *ExpectedBool
-	ExpectExpireLT(key string, expiration time.Duration) *ExpectedBool
-	ExpectKeys(pattern string) *ExpectedStringSlice
-	ExpectMigrate(host, port, key string, db int, timeout time.Duration) *ExpectedStatus
-	ExpectMove(key string, db int) *ExpectedBool
-	ExpectObjectRefCount(key string) *ExpectedInt
-	ExpectObjectEncoding(key string) *ExpectedString
-	ExpectObjectIdleTime(key string) *ExpectedDuration
-	ExpectPersist(key string) *ExpectedBool
-	ExpectPExpire(key string, expiration time.Duration) *ExpectedBool
-	ExpectPExpireAt(key string, tm time.Time) *ExpectedBool
-	ExpectPExpireTime(key string) *ExpectedDuration
-	ExpectPTTL(key string) *ExpectedDuration
-	ExpectRandomKey() *ExpectedString
-	ExpectRename(key, newkey string) *ExpectedStatus
-	ExpectRenameNX(key, newkey string) *ExpectedBool
-	ExpectRestore(key string, ttl time.Duration, value string) *ExpectedStatus
-	ExpectRestoreReplace(key string, ttl time.Duration, value string) *ExpectedStatus
-	ExpectSort(key string, sort *redis.Sort) *ExpectedStringSlice
-	ExpectSortRO(key string, sort *redis.Sort) *ExpectedStringSlice
-	ExpectSortStore(key, store string, sort *redis.Sort) *ExpectedInt
-	ExpectSortInterfaces(key string, sort *redis.Sort) *ExpectedSlice
-	ExpectTouch(keys ...string) *ExpectedInt
-	ExpectTTL(key string) *ExpectedDuration
-	ExpectType(key string) *ExpectedStatus
-	ExpectAppend(key, value string) *ExpectedInt
-	ExpectDecr(key string) *ExpectedInt
-	ExpectDecrBy(key string, decrement int64) *ExpectedInt
-	ExpectGet(key string) *ExpectedString
-	ExpectGetRange(key string, start, end int64) *ExpectedString
-	ExpectGetSet(key string, value interface{}) *ExpectedString
-	ExpectGetEx(key string, expiration time.Duration) *ExpectedString
-	ExpectGetDel(key string) *ExpectedString
-	ExpectIncr(key string) *ExpectedInt
-	ExpectIncrBy(key string, value int64) *ExpectedInt
-	ExpectIncrByFloat(key string, value float64) *ExpectedFloat
-	ExpectMGet(keys ...string) *ExpectedSlice
-	ExpectMSet(values ...interface{}) *ExpectedStatus
-	ExpectMSetNX(values ...interface{}) *ExpectedBool
-	ExpectSet(key string, value interface{}, expiration time.Duration) *ExpectedStatus
-	ExpectSetArgs(key string, value interface{}, a redis.SetArgs) *ExpectedStatus
-	ExpectSetEx(key string, value interface{}, expiration time.Duration) *ExpectedStatus
-	ExpectSetNX(key string, value interface{}, expiration time.Duration) *ExpectedBool
-	ExpectSetXX(key string, value interface{}, expiration time.Duration) *ExpectedBool
-	ExpectSetRange(key string, offset int64, value string) *ExpectedInt
-	ExpectStrLen(key string) *ExpectedInt
-	ExpectCopy(sourceKey string, destKey string, db int, replace bool) *ExpectedInt
-
-	ExpectGetBit(key string, offset int64) *ExpectedInt
-	ExpectSetBit(key string, offset int64, value int) *ExpectedInt
-	ExpectBitCount(key string, bitCount *redis.BitCount) *ExpectedInt
-	ExpectBitOpAnd(destKey string, keys ...string) *ExpectedInt
-	ExpectBitOpOr(destKey string, keys ...string) *ExpectedInt
-	ExpectBitOpXor(destKey string, keys ...string) *ExpectedInt
-	ExpectBitOpNot(destKey string, key string) *ExpectedInt
-	ExpectBitPos(key string, bit int64, pos ...int64) *ExpectedInt
-	ExpectBitPosSpan(key string, bit int8, start, end int64, span string) *ExpectedInt
-	ExpectBitField(key string, args ...interface{}) *ExpectedIntSlice
-
-	ExpectScan(cursor uint64, match string, count int64) *ExpectedScan
-	ExpectScanType(cursor uint64, match string, count int64, keyType string) *ExpectedScan
-	ExpectSScan(key string, cursor uint64, match string, count int64) *ExpectedScan
-	ExpectHScan(key string, cursor uint64, match string, count int64) *ExpectedScan
-	ExpectZScan(key string, cursor uint64, match string, count int64) *ExpectedScan
-
-	ExpectHDel(key string, fields ...string) *ExpectedInt
-	ExpectHExists(key, field string) *ExpectedBool
-	ExpectHGet(key, field string) *ExpectedString
-	ExpectHGetAll(key string) *ExpectedMapStringString
-	ExpectHIncrBy(key, field string, incr int64) *ExpectedInt
-	ExpectHIncrByFloat(key, field string, incr float64) *ExpectedFloat
-	ExpectHKeys(key string) *ExpectedStringSlice
-	ExpectHLen(key string) *ExpectedInt
-	ExpectHMGet(key string, fields ...string) *ExpectedSlice
-	ExpectHSet(key string, values ...interface{}) *ExpectedInt
-	ExpectHMSet(key string, values ...interface{}) *ExpectedBool
-	ExpectHSetNX(key, field string, value interface{}) *ExpectedBool
-	ExpectHVals(key string) *ExpectedStringSlice
-	ExpectHRandField(key string, count int) *ExpectedStringSlice
-	ExpectHRandFieldWithValues(key string, count int) *ExpectedKeyValueSlice
-
-	ExpectBLPop(timeout time.Duration, keys ...string) *ExpectedStringSlice
-	ExpectBLMPop(timeout time.Duration, direction string, count int64, keys ...string) *ExpectedKeyValues
-	ExpectBRPop(timeout time.Duration, keys ...string) *ExpectedStringSlice
-	ExpectBRPopLPush(source, destination string, timeout time.Duration) *ExpectedString
-	ExpectLCS(q *redis.LCSQuery) *ExpectedLCS
-	ExpectLIndex(key string, index int64) *ExpectedString
-	ExpectLInsert(key, op string, pivot, value interface{}) *ExpectedInt
-	ExpectLInsertBefore(key string, pivot, value interface{}) *ExpectedInt
-	ExpectLInsertAfter(key string, pivot, value interface{}) *ExpectedInt
-	ExpectLLen(key string) *ExpectedInt
-	ExpectLPop(key string) *ExpectedString
-	ExpectLPopCount(key string, count int) *ExpectedStringSlice
-	ExpectLMPop(direction string, count int64, keys ...string) *ExpectedKeyValues
-	ExpectLPos(key string, value string, args redis.LPosArgs) *ExpectedInt
-	ExpectLPosCount(key string, value string, count int64, args redis.LPosArgs) *ExpectedIntSlice
-	ExpectLPush(key string, values ...interface{}) *ExpectedInt
-	ExpectLPushX(key string, values ...interface{}) *ExpectedInt
-	ExpectLRange(key string, start, stop int64) *ExpectedStringSlice
-	ExpectLRem(key string, count int64, value interface{}) *ExpectedInt
-	ExpectLSet(key string, index int64, value interface{}) *ExpectedStatus
-	ExpectLTrim(key string, start, stop int64) *ExpectedStatus
-	ExpectRPop(key string) *ExpectedString
-	ExpectRPopCount(key string, count int) *ExpectedStringSlice
-	ExpectRPopLPush(source, destination string) *ExpectedString
-	ExpectRPush(key string, values ...interface{}) *ExpectedInt
-	ExpectRPushX(key string, values ...interface{}) *ExpectedInt
-	ExpectLMove(source, destination, srcpos, destpos string) *ExpectedString
-	ExpectBLMove(source, destination, srcpos, destpos string, timeout time.Duration) *ExpectedString
-
-	ExpectSAdd(key string, members ...interface{}) *ExpectedInt
-	ExpectSCard(key string) *ExpectedInt
-	ExpectSDiff(keys ...string) *ExpectedStringSlice
-	ExpectSDiffStore(destination string, keys ...string) *ExpectedInt
-	ExpectSInter(keys ...string) *ExpectedStringSlice
-	ExpectSInterCard(limit int64, keys ...string) *ExpectedInt
-	ExpectSInterStore(destination string, keys ...string) *ExpectedInt
-	ExpectSIsMember(key string, member interface{}) *ExpectedBool
-	ExpectSMIsMember(key string, members ...interface{}) *ExpectedBoolSlice
-	ExpectSMembers(key string) *ExpectedStringSlice
-	ExpectSMembersMap(key string) *ExpectedStringStructMap
-	ExpectSMove(source, destination string, member interface{}) *ExpectedBool
-	ExpectSPop(key string) *ExpectedString
-	ExpectSPopN(key string, count int64) *ExpectedStringSlice
-	ExpectSRandMember(key string) *ExpectedString
-	ExpectSRandMemberN(key string, count int64) *ExpectedStringSlice
-	ExpectSRem(key string, members ...interface{}) *ExpectedInt
-	ExpectSUnion(keys ...string) *ExpectedStringSlice
-	ExpectSUnionStore(destination string, keys ...string) *ExpectedInt
-
-	ExpectXAdd(a *redis.XAddArgs) *ExpectedString
-	ExpectXDel(stream string, ids ...string) *ExpectedInt
-	ExpectXLen(stream string) *ExpectedInt
-	ExpectXRange(stream, start, stop string) *ExpectedXMessageSlice
-	ExpectXRangeN(stream, start, stop string, count int64) *ExpectedXMessageSlice
-	ExpectXRevRange(stream string, start, stop string) *ExpectedXMessageSlice
-	ExpectXRevRangeN(stream string, start, stop string, count int64) *ExpectedXMessageSlice
-	ExpectXRead(a *redis.XReadArgs) *ExpectedXStreamSlice
-	ExpectXReadStreams(streams ...string) *ExpectedXStreamSlice
-	ExpectXGroupCreate(stream, group, start string) *ExpectedStatus
-	ExpectXGroupCreateMkStream(stream, group, start string) *ExpectedStatus
-	ExpectXGroupSetID(stream, group, start string) *ExpectedStatus
-	ExpectXGroupDestroy(stream, group string) *ExpectedInt
-	ExpectXGroupCreateConsumer(stream, group, consumer string) *ExpectedInt
-	ExpectXGroupDelConsumer(stream, group, consumer string) *ExpectedInt
-	ExpectXReadGroup(a *redis.XReadGroupArgs) *ExpectedXStreamSlice
-	ExpectXAck(stream, group string, ids ...string) *ExpectedInt
-	ExpectXPending(stream, group string) *ExpectedXPending
-	ExpectXPendingExt(a *redis.XPendingExtArgs) *ExpectedXPendingExt
-	ExpectXClaim(a *redis.XClaimArgs) *ExpectedXMessageSlice
-	ExpectXClaimJustID(a *redis.XClaimArgs) *ExpectedStringSlice
-	ExpectXAutoClaim(a *redis.XAutoClaimArgs) *ExpectedXAutoClaim
-	ExpectXAutoClaimJustID(a *redis.XAutoClaimArgs) *ExpectedXAutoClaimJustID
-	ExpectXTrimMaxLen(key string, maxLen int64) *ExpectedInt
-	ExpectXTrimMaxLenApprox(key string, maxLen, limit int64) *ExpectedInt
-	ExpectXTrimMinID(key string, minID string) *ExpectedInt
-	ExpectXTrimMinIDApprox(key string, minID string, limit int64) *ExpectedInt
-	ExpectXInfoGroups(key string) *ExpectedXInfoGroups
-	ExpectXInfoStream(key string) *ExpectedXInfoStream
-	ExpectXInfoStreamFull(key string, count int) *ExpectedXInfoStreamFull
-	ExpectXInfoConsumers(key string, group string) *ExpectedXInfoConsumers
-
-	ExpectBZPopMax(timeout time.Duration, keys ...string) *ExpectedZWithKey
-	ExpectBZPopMin(timeout time.Duration, keys ...string) *ExpectedZWithKey
-	ExpectBZMPop(timeout time.Duration, order string, count int64, keys ...string) *ExpectedZSliceWithKey
-
-	ExpectZAdd(key string, members ...redis.Z) *ExpectedInt
-	ExpectZAddLT(key string, members ...redis.Z) *ExpectedInt
-	ExpectZAddGT(key string, members ...redis.Z) *ExpectedInt
-	ExpectZAddNX(key string, members ...redis.Z) *ExpectedInt
-	ExpectZAddXX(key string, members ...redis.Z) *ExpectedInt
-	ExpectZAddArgs(key string, args redis.ZAddArgs) *ExpectedInt
-	ExpectZAddArgsIncr(key string, args redis.ZAddArgs) *ExpectedFloat
-	ExpectZCard(key string) *ExpectedInt
-	ExpectZCount(key, min, max string) *ExpectedInt
-	ExpectZLexCount(key, min, max string) *ExpectedInt
-	ExpectZIncrBy(key string, increment float64, member string) *ExpectedFloat
-	ExpectZInter(store *redis.ZStore) *ExpectedStringSlice
-	ExpectZInterWithScores(store *redis.ZStore) *ExpectedZSlice
-	ExpectZInterCard(limit int64, keys ...string) *ExpectedInt
-	ExpectZInterStore(destination string, store *redis.ZStore) *ExpectedInt
-	ExpectZMPop(order string, count int64, keys ...string) *ExpectedZSliceWithKey
-	ExpectZMScore(key string, members ...string) *ExpectedFloatSlice
-	ExpectZPopMax(key string, count ...int64) *ExpectedZSlice
-	ExpectZPopMin(key string, count ...int64) *ExpectedZSlice
-	ExpectZRange(key string, start, stop int64) *ExpectedStringSlice
-	ExpectZRangeWithScores(key string, start, stop int64) *ExpectedZSlice
-	ExpectZRangeByScore(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
-	ExpectZRangeByLex(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
-	ExpectZRangeByScoreWithScores(key string, opt *redis.ZRangeBy) *ExpectedZSlice
-	ExpectZRangeArgs(z redis.ZRangeArgs) *ExpectedStringSlice
-	ExpectZRangeArgsWithScores(z redis.ZRangeArgs) *ExpectedZSlice
-	ExpectZRangeStore(dst string, z redis.ZRangeArgs) *ExpectedInt
-	ExpectZRank(key, member string) *ExpectedInt
-	ExpectZRem(key string, members ...interface{}) *ExpectedInt
-	ExpectZRemRangeByRank(key string, start, stop int64) *ExpectedInt
-	ExpectZRemRangeByScore(key, min, max string) *ExpectedInt
-	ExpectZRemRangeByLex(key, min, max string) *ExpectedInt
-	ExpectZRevRange(key string, start, stop int64) *ExpectedStringSlice
-	ExpectZRevRangeWithScores(key string, start, stop int64) *ExpectedZSlice
-	ExpectZRevRangeByScore(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
-	ExpectZRevRangeByLex(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
-	ExpectZRevRangeByScoreWithScores(key string, opt *redis.ZRangeBy) *ExpectedZSlice
-	ExpectZRevRank(key, member string) *ExpectedInt
-	ExpectZScore(key, member string) *ExpectedFloat
-	ExpectZUnionStore(dest string, store *redis.ZStore) *ExpectedInt
-	ExpectZRandMember(key string, count int) *ExpectedStringSlice
-	ExpectZRandMemberWithScores(key string, count int) *ExpectedZSlice
-	ExpectZUnion(store redis.ZStore) *ExpectedStringSlice
-	ExpectZUnionWithScores(store redis.ZStore) *ExpectedZSlice
-	ExpectZDiff(keys ...string) *ExpectedStringSlice
-	ExpectZDiffWithScores(keys ...string) *ExpectedZSlice
-	ExpectZDiffStore(destination string, keys ...string) *ExpectedInt
-
-	ExpectPFAdd(key string, els ...interface{}) *ExpectedInt
-	ExpectPFCount(keys ...string) *ExpectedInt
-	ExpectPFMerge(dest string, keys ...string) *ExpectedStatus
-
-	ExpectBgRewriteAOF() *ExpectedStatus
-	ExpectBgSave() *ExpectedStatus
-	ExpectClientKill(ipPort string) *ExpectedStatus
-	ExpectClientKillByFilter(keys ...string) *ExpectedInt
-	ExpectClientList() *ExpectedString
-	ExpectClientPause(dur time.Duration) *ExpectedBool
-	ExpectClientUnpause() *ExpectedBool
-	ExpectClientID() *ExpectedInt
-	ExpectClientUnblock(id int64) *ExpectedInt
-	ExpectClientUnblockWithError(id int64) *ExpectedInt
-	ExpectConfigGet(parameter string) *ExpectedMapStringString
-	ExpectConfigResetStat() *ExpectedStatus
-	ExpectConfigSet(parameter, value string) *ExpectedStatus
-	ExpectConfigRewrite() *ExpectedStatus
-	ExpectDBSize() *ExpectedInt
-	ExpectFlushAll() *ExpectedStatus
-	ExpectFlushAllAsync() *ExpectedStatus
-	ExpectFlushDB() *ExpectedStatus
-	ExpectFlushDBAsync() *ExpectedStatus
-	ExpectInfo(section ...string) *ExpectedString
-	ExpectLastSave() *ExpectedInt
-	ExpectSave() *ExpectedStatus
-	ExpectShutdown() *ExpectedStatus
-	ExpectShutdownSave() *ExpectedStatus
-	ExpectShutdownNoSave() *ExpectedStatus
-	ExpectSlaveOf(host, port string) *ExpectedStatus
-	ExpectSlowLogGet(num int64) *ExpectedSlowLog
-	ExpectTime() *ExpectedTime
-	ExpectDebugObject(key string) *ExpectedString
-	ExpectReadOnly() *ExpectedStatus
-	ExpectReadWrite() *ExpectedStatus
-	ExpectMemoryUsage(key string, samples ...int) *ExpectedInt
-
-	ExpectEval(script string, keys []string, args ...interface{}) *ExpectedCmd
-	ExpectEvalSha(sha1 string, keys []string, args ...interface{}) *ExpectedCmd
-	ExpectEvalRO(script string, keys []string, args ...interface{}) *ExpectedCmd
-	ExpectEvalShaRO(sha1 string, keys []string, args ...interface{}) *ExpectedCmd
-	ExpectScriptExists(hashes ...string) *ExpectedBoolSlice
-	ExpectScriptFlush() *ExpectedStatus
-	ExpectScriptKill() *ExpectedStatus
-	ExpectScriptLoad(script string) *ExpectedString
-
-	ExpectPublish(channel string, message interface{}) *ExpectedInt
-	ExpectSPublish(channel string, message interface{}) *ExpectedInt
-	ExpectPubSubChannels(pattern string) *ExpectedStringSlice
-	ExpectPubSubNumSub(channels ...string) *ExpectedMapStringInt
-	ExpectPubSubNumPat() *ExpectedInt
-	ExpectPubSubShardChannels(pattern string) *ExpectedStringSlice
-	ExpectPubSubShardNumSub(channels ...string) *ExpectedMapStringInt
-
-	ExpectClusterSlots() *ExpectedClusterSlots
-	ExpectClusterShards() *ExpectedClusterShards
-	ExpectClusterLinks() *ExpectedClusterLinks
-	ExpectClusterNodes() *ExpectedString
-	ExpectClusterMeet(host, port string) *ExpectedStatus
-	ExpectClusterForget(nodeID string) *ExpectedStatus
-	ExpectClusterReplicate(nodeID string) *ExpectedStatus
-	ExpectClusterResetSoft() *ExpectedStatus
-	ExpectClusterResetHard() *ExpectedStatus
-	ExpectClusterInfo() *ExpectedString
-	ExpectClusterKeySlot(key string) *ExpectedInt
-	ExpectClusterGetKeysInSlot(slot int, count int) *ExpectedStringSlice
-	ExpectClusterCountFailureReports(nodeID string) *ExpectedInt
-	ExpectClusterCountKeysInSlot(slot int) *ExpectedInt
-	ExpectClusterDelSlots(slots ...int) *ExpectedStatus
-	ExpectClusterDelSlotsRange(min, max int) *ExpectedStatus
-	ExpectClusterSaveConfig() *ExpectedStatus
-	ExpectClusterSlaves(nodeID string) *ExpectedStringSlice
-	ExpectClusterFailover() *ExpectedStatus
-	ExpectClusterAddSlots(slots ...int) *ExpectedStatus
-	ExpectClusterAddSlotsRange(min, max int) *ExpectedStatus
-
-	ExpectGeoAdd(key string, geoLocation ...*redis.GeoLocation) *ExpectedInt
-	ExpectGeoPos(key string, members ...string) *ExpectedGeoPos
-	ExpectGeoRadius(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *ExpectedGeoLocation
-	ExpectGeoRadiusStore(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *ExpectedInt
-	ExpectGeoRadiusByMember(key, member string, query *redis.GeoRadiusQuery) *ExpectedGeoLocation
-	ExpectGeoRadiusByMemberStore(key, member string, query *redis.GeoRadiusQuery) *ExpectedInt
-	ExpectGeoSearch(key string, q *redis.GeoSearchQuery) *ExpectedStringSlice
-	ExpectGeoSearchLocation(key string, q *redis.GeoSearchLocationQuery) *ExpectedGeoSearchLocation
-	ExpectGeoSearchStore(key, store string, q *redis.GeoSearchStoreQuery) *ExpectedInt
-	ExpectGeoDist(key string, member1, member2, unit string) *ExpectedFloat
-	ExpectGeoHash(key string, members ...string) *ExpectedStringSlice
-
-	ExpectFunctionLoad(code string) *ExpectedString
-	ExpectFunctionLoadReplace(code string) *ExpectedString
-	ExpectFunctionDelete(libName string) *ExpectedString
-	ExpectFunctionFlush() *ExpectedString
-	ExpectFunctionFlushAsync() *ExpectedString
-	ExpectFunctionList(q redis.FunctionListQuery) *ExpectedFunctionList
-	ExpectFunctionKill() *ExpectedString
-	ExpectFunctionDump() *ExpectedString
-	ExpectFunctionRestore(libDump string) *ExpectedString
-	ExpectFCall(function string, keys []string, args ...interface{}) *ExpectedCmd
-	ExpectFCallRo(function string, keys []string, args ...interface{}) *ExpectedCmd
-
-	ExpectACLDryRun(username string, command ...interface{}) *ExpectedString
-
-	ExpectTSAdd(key string, timestamp interface{}, value float64) *ExpectedInt
-	ExpectTSAddWithArgs(key string, timestamp interface{}, value float64, options *redis.TSOptions) *ExpectedInt
-	ExpectTSCreate(key string) *ExpectedStatus
-	ExpectTSCreateWithArgs(key string, options *redis.TSOptions) *ExpectedStatus
-	ExpectTSAlter(key string, options *redis.TSAlterOptions) *ExpectedStatus
-	ExpectTSCreateRule(sourceKey string, destKey string, aggregator redis.Aggregator, bucketDuration int) *ExpectedStatus
-	ExpectTSCreateRuleWithArgs(sourceKey string, destKey string, aggregator redis.Aggregator, bucketDuration int, options *redis.TSCreateRuleOptions) *ExpectedStatus
-	ExpectTSIncrBy(Key string, timestamp float64) *ExpectedInt
-	ExpectTSIncrByWithArgs(key string, timestamp float64, options *redis.TSIncrDecrOptions) *ExpectedInt
-	ExpectTSDecrBy(Key string, timestamp float64) *ExpectedInt
-	ExpectTSDecrByWithArgs(key string, timestamp float64, options *redis.TSIncrDecrOptions) *ExpectedInt
-	ExpectTSDel(Key string, fromTimestamp int, toTimestamp int) *ExpectedInt
-	ExpectTSDeleteRule(sourceKey string, destKey string) *ExpectedStatus
-	ExpectTSGet(key string) *ExpectedTSTimestampValue
-	ExpectTSGetWithArgs(key string, options *redis.TSGetOptions) *ExpectedTSTimestampValue
-	ExpectTSInfo(key string) *ExpectedMapStringInterface
-	ExpectTSInfoWithArgs(key string, options *redis.TSInfoOptions) *ExpectedMapStringInterface
-	ExpectTSMAdd(ktvSlices [][]interface{}) *ExpectedIntSlice
-	ExpectTSQueryIndex(filterExpr []string) *ExpectedStringSlice
-	ExpectTSRevRange(key string, fromTimestamp int, toTimestamp int) *ExpectedTSTimestampValueSlice
-	ExpectTSRevRangeWithArgs(key string, fromTimestamp int, toTimestamp int, options *redis.TSRevRangeOptions) *ExpectedTSTimestampValueSlice
-	ExpectTSRange(key string, fromTimestamp int, toTimestamp int) *ExpectedTSTimestampValueSlice
-	ExpectTSRangeWithArgs(key string, fromTimestamp int, toTimestamp int, options *redis.TSRangeOptions) *ExpectedTSTimestampValueSlice
-	ExpectTSMRange(fromTimestamp int, toTimestamp int, filterExpr []string) *ExpectedMapStringSliceInterface
-	ExpectTSMRangeWithArgs(fromTimestamp int, toTimestamp int, filterExpr []string, options *redis.TSMRangeOptions) *ExpectedMapStringSliceInterface
-	ExpectTSMRevRange(fromTimestamp int, toTimestamp int, filterExpr []string) *ExpectedMapStringSliceInterface
-	ExpectTSMRevRangeWithArgs(fromTimestamp int, toTimestamp int, filterExpr []string, options *redis.TSMRevRangeOptions) *ExpectedMapStringSliceInterface
-	ExpectTSMGet(filters []string) *ExpectedMapStringSliceInterface
-	ExpectTSMGetWithArgs(filters []string, options *redis.TSMGetOptions) *ExpectedMapStringSliceInterface
+
+	// ExpectClientTracking expects CLIENT TRACKING ON with the options, or OFF. The returned
+	// expectation sends the invalidation messages to a client of a Server.
+	ExpectClientTracking(on bool, opt *TrackingOptions) *ExpectedTracking
+
+	// ExpectScript expects a Lua script run by EVAL or EVALSHA, see ExpectedScript.
+	ExpectScript(script string, keys []string, args ...interface{}) *ExpectedScript
+
+	// ExpectScanAll, ExpectSScanAll, ExpectHScanAll and ExpectZScanAll expect the full iteration
+	// of a scan over the elements, in pages of pageSize elements, see ExpectedScanAll.
+	ExpectScanAll(keys []string, pageSize int) *ExpectedScanAll
+	ExpectSScanAll(key string, members []string, pageSize int) *ExpectedScanAll
+	ExpectHScanAll(key string, fields map[string]string, pageSize int) *ExpectedScanAll
+	ExpectZScanAll(key string, members []redis.Z, pageSize int) *ExpectedScanAll
+
+	// Chaos injects faults into a fraction of the commands of the client at random from the seed,
+	// a nil policy stops it. ChaosReport returns the faults injected.
+	Chaos(seed int64, policy *ChaosPolicy)
+	ChaosReport() []ChaosEvent
+
+	// RunLua runs the Lua scripts against the fake of the mock, see NewClientFakeMock.
+	RunLua(on bool)
+
+	expectCmdable
 }
 
 type pipelineMock interface {
@@ -413,20 +106,51 @@ type ClientMock interface {
 
 type ClusterClientMock interface {
 	baseMock
+
+	Node(name string) *mock
+	CheckCrossSlot(on bool)
+}
+
+// inflow writes val into cmd through the public SetVal method of the go-redis Cmd type.
+func inflow[T any](cmd redis.Cmder, val T) error {
+	if c, ok := cmd.(interface{ SetVal(T) }); ok {
+		c.SetVal(val)
+		return nil
+	}
+	return inflowReflect(cmd, val)
+}
+
+// inflowPair is inflow for the Cmd types whose SetVal takes two values, such as *redis.ScanCmd.
+func inflowPair[A, B any](cmd redis.Cmder, a A, b B) error {
+	if c, ok := cmd.(interface{ SetVal(A, B) }); ok {
+		c.SetVal(a, b)
+		return nil
+	}
+	return inflowReflect(cmd, a, b)
 }
 
-func inflow(cmd redis.Cmder, key string, val interface{}) {
-	v := reflect.ValueOf(cmd).Elem().FieldByName(key)
-	if !v.IsValid() {
-		panic(fmt.Sprintf("cmd did not find key '%s'", key))
+// inflowReflect is the fallback of inflow when the type of the expected value differs from the Cmd value,
+// for example ExpectDo matched by a typed command, it calls SetVal if the values are assignable.
+func inflowReflect(cmd redis.Cmder, vals ...interface{}) error {
+	fn := reflect.ValueOf(cmd).MethodByName("SetVal")
+	if !fn.IsValid() || fn.Type().NumIn() != len(vals) {
+		return fmt.Errorf("cmd(%s), %T does not accept %d value(s)", cmd.Name(), cmd, len(vals))
 	}
-	v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
 
-	setVal := reflect.ValueOf(val)
-	if v.Kind() != reflect.Interface && setVal.Kind() != v.Kind() {
-		panic(fmt.Sprintf("expected kind %v, got kind: %v", v.Kind(), setVal.Kind()))
+	in := make([]reflect.Value, len(vals))
+	for i, val := range vals {
+		typ := fn.Type().In(i)
+		v := reflect.ValueOf(val)
+		switch {
+		case !v.IsValid():
+			v = reflect.Zero(typ)
+		case !v.Type().AssignableTo(typ):
+			return fmt.Errorf("cmd(%s), %T expected value of type %v, got %T", cmd.Name(), cmd, typ, val)
+		}
+		in[i] = v
 	}
-	v.Set(setVal)
+	fn.Call(in)
+	return nil
 }
 
 type expectation interface {
@@ -434,11 +158,16 @@ type expectation interface {
 	setRegexpMatch()
 	custom() CustomMatch
 	setCustomMatch(fn CustomMatch)
+	setClock(c *Clock)
+	scope() string
+	setScope(node string)
 	usable() bool
 	trigger()
+	blocker() *Blocking
 
 	name() string
 	args() []interface{}
+	command() redis.Cmder
 
 	error() error
 	SetErr(err error)
@@ -446,7 +175,7 @@ type expectation interface {
 	RedisNil()
 	isRedisNil() bool
 
-	inflow(c redis.Cmder)
+	inflow(c redis.Cmder) error
 
 	isSetVal() bool
 
@@ -464,6 +193,9 @@ type expectedBase struct {
 	setVal      bool
 	regexpMatch bool
 	customMatch CustomMatch
+	clock       *Clock
+	node        string
+	blocking    *Blocking
 
 	rw sync.RWMutex
 }
@@ -492,6 +224,18 @@ func (base *expectedBase) setCustomMatch(fn CustomMatch) {
 	base.customMatch = fn
 }
 
+func (base *expectedBase) setClock(c *Clock) {
+	base.clock = c
+}
+
+func (base *expectedBase) scope() string {
+	return base.node
+}
+
+func (base *expectedBase) setScope(node string) {
+	base.node = node
+}
+
 func (base *expectedBase) usable() bool {
 	return !base.triggered
 }
@@ -508,6 +252,10 @@ func (base *expectedBase) args() []interface{} {
 	return base.cmd.Args()
 }
 
+func (base *expectedBase) command() redis.Cmder {
+	return base.cmd
+}
+
 func (base *expectedBase) SetErr(err error) {
 	base.err = err
 }
@@ -544,8 +292,8 @@ func (cmd *ExpectedCommandsInfo) SetVal(val []*redis.CommandInfo) {
 	}
 }
 
-func (cmd *ExpectedCommandsInfo) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedCommandsInfo) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -561,8 +309,8 @@ func (cmd *ExpectedString) SetVal(val string) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedString) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedString) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -578,8 +326,8 @@ func (cmd *ExpectedStatus) SetVal(val string) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedStatus) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedStatus) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -595,8 +343,8 @@ func (cmd *ExpectedInt) SetVal(val int64) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedInt) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedInt) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -612,8 +360,8 @@ func (cmd *ExpectedBool) SetVal(val bool) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedBool) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedBool) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -630,8 +378,8 @@ func (cmd *ExpectedStringSlice) SetVal(val []string) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedStringSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedStringSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -648,8 +396,8 @@ func (cmd *ExpectedKeyValueSlice) SetVal(val []redis.KeyValue) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedKeyValueSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedKeyValueSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -659,15 +407,49 @@ type ExpectedDuration struct {
 
 	val time.Duration
 	// precision time.Duration
+
+	expireAt time.Time
 }
 
 func (cmd *ExpectedDuration) SetVal(val time.Duration) {
 	cmd.setVal = true
 	cmd.val = val
+	cmd.expireAt = time.Time{}
 }
 
-func (cmd *ExpectedDuration) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+// SetValExpireAt sets the value of TTL and PTTL from the expiration time of the key,
+// the remaining time is computed with the mock Clock when the command is called.
+// For EXPIRETIME and PEXPIRETIME the value is the timestamp of tm.
+func (cmd *ExpectedDuration) SetValExpireAt(tm time.Time) {
+	cmd.setVal = true
+	cmd.expireAt = tm
+}
+
+func (cmd *ExpectedDuration) inflow(c redis.Cmder) error {
+	if cmd.expireAt.IsZero() {
+		return inflow(c, cmd.val)
+	}
+
+	var val time.Duration
+	switch c.Name() {
+	case "ttl", "pttl":
+		unit := time.Second
+		if c.Name() == "pttl" {
+			unit = time.Millisecond
+		}
+		val = cmd.expireAt.Sub(cmd.clock.Now()).Round(unit)
+		if val <= 0 {
+			// the key has expired
+			val = -2
+		}
+	case "expiretime":
+		val = time.Duration(cmd.expireAt.Unix()) * time.Second
+	case "pexpiretime":
+		val = time.Duration(cmd.expireAt.UnixMilli()) * time.Millisecond
+	default:
+		return fmt.Errorf("cmd(%s), SetValExpireAt is not supported", c.Name())
+	}
+	return inflow(c, val)
 }
 
 // ------------------------------------------------------------
@@ -684,8 +466,8 @@ func (cmd *ExpectedSlice) SetVal(val []interface{}) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -701,8 +483,8 @@ func (cmd *ExpectedFloat) SetVal(val float64) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedFloat) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedFloat) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -719,8 +501,8 @@ func (cmd *ExpectedFloatSlice) SetVal(val []float64) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedFloatSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedFloatSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -737,8 +519,8 @@ func (cmd *ExpectedIntSlice) SetVal(val []int64) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedIntSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedIntSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -757,9 +539,8 @@ func (cmd *ExpectedScan) SetVal(page []string, cursor uint64) {
 	cmd.cursor = cursor
 }
 
-func (cmd *ExpectedScan) inflow(c redis.Cmder) {
-	inflow(c, "page", cmd.page)
-	inflow(c, "cursor", cmd.cursor)
+func (cmd *ExpectedScan) inflow(c redis.Cmder) error {
+	return inflowPair(c, cmd.page, cmd.cursor)
 }
 
 // ------------------------------------------------------------
@@ -778,8 +559,8 @@ func (cmd *ExpectedMapStringString) SetVal(val map[string]string) {
 	}
 }
 
-func (cmd *ExpectedMapStringString) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedMapStringString) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -798,8 +579,8 @@ func (cmd *ExpectedStringStructMap) SetVal(val []string) {
 	}
 }
 
-func (cmd *ExpectedStringStructMap) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedStringStructMap) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -816,8 +597,8 @@ func (cmd *ExpectedXMessageSlice) SetVal(val []redis.XMessage) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedXMessageSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXMessageSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -834,8 +615,8 @@ func (cmd *ExpectedXStreamSlice) SetVal(val []redis.XStream) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedXStreamSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXStreamSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -852,8 +633,8 @@ func (cmd *ExpectedXPending) SetVal(val *redis.XPending) {
 	cmd.val = &v
 }
 
-func (cmd *ExpectedXPending) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXPending) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -870,8 +651,8 @@ func (cmd *ExpectedXPendingExt) SetVal(val []redis.XPendingExt) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedXPendingExt) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXPendingExt) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ----------------------------------------------------------------------
@@ -890,9 +671,8 @@ func (cmd *ExpectedXAutoClaim) SetVal(val []redis.XMessage, start string) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedXAutoClaim) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
-	inflow(c, "start", cmd.start)
+func (cmd *ExpectedXAutoClaim) inflow(c redis.Cmder) error {
+	return inflowPair(c, cmd.val, cmd.start)
 }
 
 // ------------------------------------------------------------
@@ -911,9 +691,8 @@ func (cmd *ExpectedXAutoClaimJustID) SetVal(val []string, start string) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedXAutoClaimJustID) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
-	inflow(c, "start", cmd.start)
+func (cmd *ExpectedXAutoClaimJustID) inflow(c redis.Cmder) error {
+	return inflowPair(c, cmd.val, cmd.start)
 }
 
 // ------------------------------------------------------------
@@ -930,8 +709,8 @@ func (cmd *ExpectedXInfoGroups) SetVal(val []redis.XInfoGroup) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedXInfoGroups) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXInfoGroups) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -948,8 +727,8 @@ func (cmd *ExpectedXInfoStream) SetVal(val *redis.XInfoStream) {
 	cmd.val = &v
 }
 
-func (cmd *ExpectedXInfoStream) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXInfoStream) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -966,8 +745,8 @@ func (cmd *ExpectedXInfoConsumers) SetVal(val []redis.XInfoConsumer) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedXInfoConsumers) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXInfoConsumers) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -984,8 +763,8 @@ func (cmd *ExpectedXInfoStreamFull) SetVal(val *redis.XInfoStreamFull) {
 	cmd.val = &v
 }
 
-func (cmd *ExpectedXInfoStreamFull) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedXInfoStreamFull) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1002,8 +781,8 @@ func (cmd *ExpectedZWithKey) SetVal(val *redis.ZWithKey) {
 	cmd.val = &v
 }
 
-func (cmd *ExpectedZWithKey) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedZWithKey) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1020,8 +799,8 @@ func (cmd *ExpectedZSlice) SetVal(val []redis.Z) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedZSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedZSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1037,8 +816,8 @@ func (cmd *ExpectedTime) SetVal(val time.Time) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedTime) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedTime) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1054,8 +833,41 @@ func (cmd *ExpectedCmd) SetVal(val interface{}) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedCmd) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedCmd) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// SetRESP sets the reply decoded from raw RESP2/RESP3 bytes as go-redis would, an error reply
+// is set with SetErr and a null reply with RedisNil, see ParseRESP.
+func (cmd *ExpectedCmd) SetRESP(raw []byte) {
+	setRESP(cmd, raw)
+}
+
+// SetRESPText is SetRESP for a readable description of the reply, see ParseRESPText.
+func (cmd *ExpectedCmd) SetRESPText(text string) {
+	raw, err := respFromText(text)
+	if err != nil {
+		panic(err)
+	}
+	setRESP(cmd, raw)
+}
+
+// ------------------------------------------------------------
+
+// ExpectedOf is the expectation returned by ExpectCmd, V is the value type of the go-redis Cmd.
+type ExpectedOf[V any] struct {
+	expectedBase
+
+	val V
+}
+
+func (cmd *ExpectedOf[V]) SetVal(val V) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedOf[V]) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1072,8 +884,8 @@ func (cmd *ExpectedBoolSlice) SetVal(val []bool) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedBoolSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedBoolSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1090,8 +902,8 @@ func (cmd *ExpectedClusterSlots) SetVal(val []redis.ClusterSlot) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedClusterSlots) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedClusterSlots) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1108,8 +920,8 @@ func (cmd *ExpectedClusterLinks) SetVal(val []redis.ClusterLink) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedClusterLinks) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedClusterLinks) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1128,8 +940,8 @@ func (cmd *ExpectedMapStringInt) SetVal(val map[string]int64) {
 	}
 }
 
-func (cmd *ExpectedMapStringInt) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedMapStringInt) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1146,8 +958,8 @@ func (cmd *ExpectedGeoPos) SetVal(val []*redis.GeoPos) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedGeoPos) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedGeoPos) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1164,8 +976,8 @@ func (cmd *ExpectedGeoLocation) SetVal(val []redis.GeoLocation) {
 	copy(cmd.locations, val)
 }
 
-func (cmd *ExpectedGeoLocation) inflow(c redis.Cmder) {
-	inflow(c, "locations", cmd.locations)
+func (cmd *ExpectedGeoLocation) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.locations)
 }
 
 // ------------------------------------------------------------
@@ -1182,8 +994,8 @@ func (cmd *ExpectedGeoSearchLocation) SetVal(val []redis.GeoLocation) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedGeoSearchLocation) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedGeoSearchLocation) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1202,9 +1014,8 @@ func (cmd *ExpectedKeyValues) SetVal(key string, val []string) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedKeyValues) inflow(c redis.Cmder) {
-	inflow(c, "key", cmd.key)
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedKeyValues) inflow(c redis.Cmder) error {
+	return inflowPair(c, cmd.key, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1223,9 +1034,8 @@ func (cmd *ExpectedZSliceWithKey) SetVal(key string, val []redis.Z) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedZSliceWithKey) inflow(c redis.Cmder) {
-	inflow(c, "key", cmd.key)
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedZSliceWithKey) inflow(c redis.Cmder) error {
+	return inflowPair(c, cmd.key, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1242,8 +1052,8 @@ func (cmd *ExpectedSlowLog) SetVal(val []redis.SlowLog) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedSlowLog) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedSlowLog) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1260,8 +1070,8 @@ func (cmd *ExpectedFunctionList) SetVal(val []redis.Library) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedFunctionList) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedFunctionList) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1278,8 +1088,8 @@ func (cmd *ExpectedLCS) SetVal(val *redis.LCSMatch) {
 	cmd.val = &v
 }
 
-func (cmd *ExpectedLCS) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedLCS) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1296,8 +1106,8 @@ func (cmd *ExpectedKeyFlags) SetVal(val []redis.KeyFlags) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedKeyFlags) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedKeyFlags) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1314,8 +1124,8 @@ func (cmd *ExpectedClusterShards) SetVal(val []redis.ClusterShard) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedClusterShards) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedClusterShards) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1331,8 +1141,8 @@ func (cmd *ExpectedTSTimestampValue) SetVal(val redis.TSTimestampValue) {
 	cmd.val = val
 }
 
-func (cmd *ExpectedTSTimestampValue) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedTSTimestampValue) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1351,8 +1161,8 @@ func (cmd *ExpectedMapStringInterface) SetVal(val map[string]interface{}) {
 	}
 }
 
-func (cmd *ExpectedMapStringInterface) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedMapStringInterface) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1369,8 +1179,8 @@ func (cmd *ExpectedTSTimestampValueSlice) SetVal(val []redis.TSTimestampValue) {
 	copy(cmd.val, val)
 }
 
-func (cmd *ExpectedTSTimestampValueSlice) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedTSTimestampValueSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1390,8 +1200,198 @@ func (cmd *ExpectedMapStringSliceInterface) SetVal(val map[string][]interface{})
 	}
 }
 
-func (cmd *ExpectedMapStringSliceInterface) inflow(c redis.Cmder) {
-	inflow(c, "val", cmd.val)
+func (cmd *ExpectedMapStringSliceInterface) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedClientInfo struct {
+	expectedBase
+
+	val *redis.ClientInfo
+}
+
+func (cmd *ExpectedClientInfo) SetVal(val *redis.ClientInfo) {
+	cmd.setVal = true
+	v := *val
+	cmd.val = &v
+}
+
+func (cmd *ExpectedClientInfo) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedACLLog struct {
+	expectedBase
+
+	val []*redis.ACLLogEntry
+}
+
+func (cmd *ExpectedACLLog) SetVal(val []*redis.ACLLogEntry) {
+	cmd.setVal = true
+	cmd.val = make([]*redis.ACLLogEntry, len(val))
+	copy(cmd.val, val)
+}
+
+func (cmd *ExpectedACLLog) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedRankWithScore struct {
+	expectedBase
+
+	val redis.RankScore
+}
+
+func (cmd *ExpectedRankWithScore) SetVal(val redis.RankScore) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedRankWithScore) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedFunctionStats struct {
+	expectedBase
+
+	val redis.FunctionStats
+}
+
+func (cmd *ExpectedFunctionStats) SetVal(val redis.FunctionStats) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedFunctionStats) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedMapStringInterfaceSlice struct {
+	expectedBase
+
+	val []map[string]interface{}
+}
+
+func (cmd *ExpectedMapStringInterfaceSlice) SetVal(val []map[string]interface{}) {
+	cmd.setVal = true
+	cmd.val = make([]map[string]interface{}, len(val))
+	copy(cmd.val, val)
+}
+
+func (cmd *ExpectedMapStringInterfaceSlice) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedBFInfo struct {
+	expectedBase
+
+	val redis.BFInfo
+}
+
+func (cmd *ExpectedBFInfo) SetVal(val redis.BFInfo) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedBFInfo) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedScanDump struct {
+	expectedBase
+
+	val redis.ScanDump
+}
+
+func (cmd *ExpectedScanDump) SetVal(val redis.ScanDump) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedScanDump) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedCFInfo struct {
+	expectedBase
+
+	val redis.CFInfo
+}
+
+func (cmd *ExpectedCFInfo) SetVal(val redis.CFInfo) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedCFInfo) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedCMSInfo struct {
+	expectedBase
+
+	val redis.CMSInfo
+}
+
+func (cmd *ExpectedCMSInfo) SetVal(val redis.CMSInfo) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedCMSInfo) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedTopKInfo struct {
+	expectedBase
+
+	val redis.TopKInfo
+}
+
+func (cmd *ExpectedTopKInfo) SetVal(val redis.TopKInfo) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedTopKInfo) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
+}
+
+// ------------------------------------------------------------
+
+type ExpectedTDigestInfo struct {
+	expectedBase
+
+	val redis.TDigestInfo
+}
+
+func (cmd *ExpectedTDigestInfo) SetVal(val redis.TDigestInfo) {
+	cmd.setVal = true
+	cmd.val = val
+}
+
+func (cmd *ExpectedTDigestInfo) inflow(c redis.Cmder) error {
+	return inflow(c, cmd.val)
 }
 
 // ------------------------------------------------------------
@@ -1400,4 +1400,6 @@ type ExpectedError struct {
 	expectedBase
 }
 
-func (cmd *ExpectedError) inflow(c redis.Cmder) {}
+func (cmd *ExpectedError) inflow(c redis.Cmder) error {
+	return nil
+}
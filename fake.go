@@ -0,0 +1,442 @@
+package redismock
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewClientFake returns a client whose commands are executed against an in-memory store
+// instead of the expectation list, see the README for the supported commands.
+func NewClientFake() *redis.Client {
+	m := newMock(redisClient)
+	m.fake = newFake(m)
+	return m.client.(*redis.Client)
+}
+
+// NewClusterFake is NewClientFake for a cluster client, all the slots are served by one store
+// and the commands are not checked for keys in several slots.
+func NewClusterFake() *redis.ClusterClient {
+	m := newMock(redisCluster)
+	m.crossSlot = false
+	m.fake = newFake(m)
+	return m.client.(*redis.ClusterClient)
+}
+
+// NewClientFakeMock returns a client checking the expectations first, the commands matching
+// none of them are executed against an in-memory store instead of being unexpected calls.
+// In strict order, only the next expectation is checked.
+func NewClientFakeMock() (*redis.Client, ClientMock) {
+	m := newMock(redisClient)
+	m.fake = newFake(m)
+	return m.client.(*redis.Client), m
+}
+
+// NewClusterFakeMock is NewClientFakeMock for a cluster client.
+func NewClusterFakeMock() (*redis.ClusterClient, ClusterClientMock) {
+	m := newMock(redisCluster)
+	m.fake = newFake(m)
+	return m.client.(*redis.ClusterClient), m
+}
+
+// fake sends the commands to the store through a client connected in memory,
+// so the replies are decoded by go-redis into the typed Cmd.
+type fake struct {
+	store  *fakeStore
+	client *redis.Client
+}
+
+func newFake(m *mock) *fake {
+	f := &fake{store: newFakeStore(m.clock.Now)}
+	f.store.notifier = m.notifier
+	f.client = f.newClient(2)
+	m.clock.watch(f.store.expire)
+	return f
+}
+
+func (f *fake) newClient(protocol int) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Dialer: func(_ context.Context, _, _ string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go serveRESP(server, f.store.handle)
+			return client, nil
+		},
+		Protocol:         protocol,
+		DisableIndentity: true,
+		MaxRetries:       -1,
+	})
+}
+
+// setProtocol reconnects to the store with the protocol, 0 is RESP2.
+func (f *fake) setProtocol(protocol int) {
+	if protocol == 0 {
+		protocol = 2
+	}
+	_ = f.client.Close()
+	f.client = f.newClient(protocol)
+}
+
+func (f *fake) process(cmd redis.Cmder) error {
+	if w, ok := cmd.(*wireCmd); ok {
+		return f.processWire(w)
+	}
+
+	// the commands of a transaction come one by one from the hook,
+	// they are executed as they are sent instead of being queued
+	switch cmd.Name() {
+	case "multi", "discard":
+		return inflowReflect(cmd, "OK")
+	case "exec":
+		return inflowReflect(cmd, []interface{}{})
+	}
+	return f.client.Process(context.Background(), cmd)
+}
+
+// processWire executes a command received by a Server, the transaction is handled by the Server.
+func (f *fake) processWire(w *wireCmd) error {
+	switch w.Name() {
+	case "multi", "discard":
+		w.reply = respStatus("OK")
+		return nil
+	case "exec":
+		w.reply = []interface{}{}
+		return nil
+	}
+
+	w.reply = f.store.handle(w.conn, w.wire)
+	if err, ok := w.reply.(error); ok {
+		w.SetErr(err)
+		return err
+	}
+	return nil
+}
+
+//------------------------------------------------------------------
+
+const (
+	errWrongType   = respError(msgWrongType)
+	errSyntax      = respError("ERR syntax error")
+	errNotInt      = respError("ERR value is not an integer or out of range")
+	errNotFloat    = respError("ERR value is not a valid float")
+	errNoSuchKey   = respError("ERR no such key")
+	errOutOfRange  = respError("ERR index out of range")
+	errOverflow    = respError("ERR increment or decrement would overflow")
+	errMinMaxFloat = respError("ERR min or max is not a float")
+	errMinMaxLex   = respError("ERR min or max not valid string range item")
+)
+
+func errArity(name string) respError {
+	return respError("ERR wrong number of arguments for '" + name + "' command")
+}
+
+type fakeList struct {
+	items []string
+}
+
+type fakeEntry struct {
+	// string, map[string]string (hash), *fakeList, map[string]struct{} (set), map[string]float64 (zset)
+	// or *fakeStream
+	val      interface{}
+	expireAt time.Time
+}
+
+func (e *fakeEntry) typ() string {
+	switch e.val.(type) {
+	case string:
+		return "string"
+	case map[string]string:
+		return "hash"
+	case *fakeList:
+		return "list"
+	case map[string]struct{}:
+		return "set"
+	case map[string]float64:
+		return "zset"
+	case *fakeStream:
+		return "stream"
+	}
+	return "none"
+}
+
+// fakeStore is the in-memory data of the fake, one database.
+type fakeStore struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	keys   map[string]*fakeEntry
+	config map[string]string
+
+	// notifier publishes the keyspace events of the commands, collected while the store is locked,
+	// the deletions of the emptied keys are published after the event of the command
+	notifier *keyspaceNotifier
+	events   []fakeEvent
+	trailing []fakeEvent
+
+	// lua enables the scripts, see RunLua, scripts maps the SHA1 of the cached scripts to their source
+	lua       bool
+	scripts   map[string]string
+	libraries map[string]*fakeLibrary
+}
+
+type fakeEvent struct {
+	event, key string
+}
+
+func newFakeStore(now func() time.Time) *fakeStore {
+	return &fakeStore{
+		now:    now,
+		keys:   make(map[string]*fakeEntry),
+		config: make(map[string]string),
+	}
+}
+
+type fakeCommand struct {
+	// arity as in COMMAND INFO, the name included, negative for a minimum
+	arity int
+	fn    func(s *fakeStore, args []string) interface{}
+}
+
+func (s *fakeStore) handle(c *respConn, args []string) interface{} {
+	switch name := strings.ToLower(args[0]); name {
+	case "multi":
+		if c.multi {
+			return respError("ERR MULTI calls can not be nested")
+		}
+		c.multi, c.dirty, c.queued = true, false, nil
+		return respStatus("OK")
+	case "discard":
+		if !c.multi {
+			return respError("ERR DISCARD without MULTI")
+		}
+		c.multi, c.queued = false, nil
+		return respStatus("OK")
+	case "exec":
+		if !c.multi {
+			return respError("ERR EXEC without MULTI")
+		}
+		queued, dirty := c.queued, c.dirty
+		c.multi, c.queued = false, nil
+		if dirty {
+			return respError("EXECABORT Transaction discarded because of previous errors.")
+		}
+
+		s.mu.Lock()
+		replies := make([]interface{}, len(queued))
+		for i, args := range queued {
+			replies[i] = s.run(args)
+		}
+		s.unlock()
+		return replies
+	}
+
+	_, err := lookupFakeCommand(args)
+	if err != nil {
+		c.dirty = c.multi
+		return err
+	}
+	if c.multi {
+		c.queued = append(c.queued, args)
+		return respStatus("QUEUED")
+	}
+
+	s.mu.Lock()
+	reply := s.run(args)
+	s.unlock()
+	return reply
+}
+
+// run executes a command while the store is locked.
+func (s *fakeStore) run(args []string) interface{} {
+	name := strings.ToLower(args[0])
+	reply := fakeCommands[name].fn(s, args[1:])
+	if n, ok := fakeNotifications[name]; ok {
+		for _, key := range n.keys(args[1:], reply) {
+			s.notify(n.event, key)
+		}
+	}
+	s.events = append(s.events, s.trailing...)
+	s.trailing = nil
+	return reply
+}
+
+// unlock unlocks the store and publishes the events of the commands.
+func (s *fakeStore) unlock() {
+	events := s.events
+	s.events = nil
+	s.mu.Unlock()
+
+	if s.notifier == nil {
+		return
+	}
+	for _, e := range events {
+		s.notifier.emit(e.event, e.key)
+	}
+}
+
+// notify records a keyspace event, the store is locked.
+func (s *fakeStore) notify(event, key string) {
+	s.events = append(s.events, fakeEvent{event, key})
+}
+
+// expire removes the expired keys, as Redis does in the background.
+func (s *fakeStore) expire() {
+	s.mu.Lock()
+	s.sortedKeys()
+	s.unlock()
+}
+
+func lookupFakeCommand(args []string) (fakeCommand, error) {
+	name := strings.ToLower(args[0])
+	cmd, ok := fakeCommands[name]
+	if !ok {
+		return cmd, respError("ERR unknown command '" + args[0] + "'")
+	}
+	if (cmd.arity > 0 && len(args) != cmd.arity) || (cmd.arity < 0 && len(args) < -cmd.arity) {
+		return cmd, errArity(name)
+	}
+	return cmd, nil
+}
+
+// get returns the entry of a key, expired keys are removed.
+func (s *fakeStore) get(key string) *fakeEntry {
+	e, ok := s.keys[key]
+	if !ok {
+		return nil
+	}
+	if !e.expireAt.IsZero() && !s.now().Before(e.expireAt) {
+		delete(s.keys, key)
+		s.notify("expired", key)
+		return nil
+	}
+	return e
+}
+
+func (s *fakeStore) set(key string, val interface{}) *fakeEntry {
+	if s.get(key) == nil {
+		s.notify("new", key)
+	}
+	e := &fakeEntry{val: val}
+	s.keys[key] = e
+	return e
+}
+
+func (s *fakeStore) del(key string) bool {
+	if s.get(key) == nil {
+		return false
+	}
+	delete(s.keys, key)
+	return true
+}
+
+// sortedKeys returns the keys not expired, sorted for a stable SCAN.
+func (s *fakeStore) sortedKeys() []string {
+	keys := make([]string, 0, len(s.keys))
+	for key := range s.keys {
+		if s.get(key) != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *fakeStore) getString(key string) (string, bool, error) {
+	e := s.get(key)
+	if e == nil {
+		return "", false, nil
+	}
+	v, ok := e.val.(string)
+	if !ok {
+		return "", false, errWrongType
+	}
+	return v, true, nil
+}
+
+func (s *fakeStore) getHash(key string, create bool) (map[string]string, error) {
+	e := s.get(key)
+	if e == nil {
+		if !create {
+			return nil, nil
+		}
+		e = s.set(key, make(map[string]string))
+	}
+	v, ok := e.val.(map[string]string)
+	if !ok {
+		return nil, errWrongType
+	}
+	return v, nil
+}
+
+func (s *fakeStore) getList(key string, create bool) (*fakeList, error) {
+	e := s.get(key)
+	if e == nil {
+		if !create {
+			return nil, nil
+		}
+		e = s.set(key, &fakeList{})
+	}
+	v, ok := e.val.(*fakeList)
+	if !ok {
+		return nil, errWrongType
+	}
+	return v, nil
+}
+
+func (s *fakeStore) getSet(key string, create bool) (map[string]struct{}, error) {
+	e := s.get(key)
+	if e == nil {
+		if !create {
+			return nil, nil
+		}
+		e = s.set(key, make(map[string]struct{}))
+	}
+	v, ok := e.val.(map[string]struct{})
+	if !ok {
+		return nil, errWrongType
+	}
+	return v, nil
+}
+
+func (s *fakeStore) getZSet(key string, create bool) (map[string]float64, error) {
+	e := s.get(key)
+	if e == nil {
+		if !create {
+			return nil, nil
+		}
+		e = s.set(key, make(map[string]float64))
+	}
+	v, ok := e.val.(map[string]float64)
+	if !ok {
+		return nil, errWrongType
+	}
+	return v, nil
+}
+
+// cleanup removes a key holding an empty aggregate, as Redis does.
+func (s *fakeStore) cleanup(key string) {
+	e := s.get(key)
+	if e == nil {
+		return
+	}
+	var n int
+	switch v := e.val.(type) {
+	case string, *fakeStream:
+		return
+	case map[string]string:
+		n = len(v)
+	case *fakeList:
+		n = len(v.items)
+	case map[string]struct{}:
+		n = len(v)
+	case map[string]float64:
+		n = len(v)
+	}
+	if n == 0 {
+		delete(s.keys, key)
+		s.trailing = append(s.trailing, fakeEvent{"del", key})
+	}
+}
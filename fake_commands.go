@@ -0,0 +1,2224 @@
+package redismock
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var fakeCommands map[string]fakeCommand
+
+func init() {
+	fakeCommands = map[string]fakeCommand{
+		// connection, MULTI/EXEC/DISCARD are handled by the store
+		"ping":    {-1, fakePing},
+		"echo":    {2, func(_ *fakeStore, args []string) interface{} { return args[0] }},
+		"select":  {2, fakeOK},
+		"quit":    {1, fakeOK},
+		"watch":   {-2, fakeOK},
+		"unwatch": {1, fakeOK},
+		"config":  {-2, fakeConfig},
+
+		// scripting, see RunLua
+		"eval":       {-3, fakeEval},
+		"evalsha":    {-3, fakeEvalSHA},
+		"eval_ro":    {-3, fakeEvalRO},
+		"evalsha_ro": {-3, fakeEvalSHARO},
+		"fcall":      {-3, fakeFCall},
+		"fcall_ro":   {-3, fakeFCallRO},
+		"script":     {-2, fakeScript},
+		"function":   {-2, fakeFunction},
+
+		// streams, see stream.go
+		"xadd":       {-5, fakeXAdd},
+		"xlen":       {2, fakeXLen},
+		"xrange":     {-4, fakeXRange(false)},
+		"xrevrange":  {-4, fakeXRange(true)},
+		"xdel":       {-3, fakeXDel},
+		"xtrim":      {-4, fakeXTrim},
+		"xsetid":     {-3, fakeXSetID},
+		"xread":      {-4, fakeXRead},
+		"xreadgroup": {-7, fakeXReadGroup},
+		"xgroup":     {-2, fakeXGroup},
+		"xack":       {-4, fakeXAck},
+		"xpending":   {-3, fakeXPending},
+		"xclaim":     {-6, fakeXClaim},
+		"xautoclaim": {-6, fakeXAutoClaim},
+		"xinfo":      {-2, fakeXInfo},
+
+		// keys
+		"del":         {-2, fakeDel},
+		"unlink":      {-2, fakeDel},
+		"exists":      {-2, fakeExists},
+		"touch":       {-2, fakeExists},
+		"type":        {2, fakeType},
+		"keys":        {2, fakeKeys},
+		"scan":        {-2, fakeScan},
+		"dbsize":      {1, func(s *fakeStore, _ []string) interface{} { return int64(len(s.sortedKeys())) }},
+		"flushdb":     {-1, fakeFlush},
+		"flushall":    {-1, fakeFlush},
+		"rename":      {3, fakeRename(false)},
+		"renamenx":    {3, fakeRename(true)},
+		"randomkey":   {1, fakeRandomKey},
+		"expire":      {-3, fakeExpire(time.Second, false)},
+		"pexpire":     {-3, fakeExpire(time.Millisecond, false)},
+		"expireat":    {-3, fakeExpire(time.Second, true)},
+		"pexpireat":   {-3, fakeExpire(time.Millisecond, true)},
+		"ttl":         {2, fakeTTL(time.Second, false)},
+		"pttl":        {2, fakeTTL(time.Millisecond, false)},
+		"expiretime":  {2, fakeTTL(time.Second, true)},
+		"pexpiretime": {2, fakeTTL(time.Millisecond, true)},
+		"persist":     {2, fakePersist},
+
+		// strings
+		"get":         {2, fakeGet},
+		"set":         {-3, fakeSet},
+		"setnx":       {3, fakeSetNX},
+		"setex":       {4, fakeSetEx(time.Second)},
+		"psetex":      {4, fakeSetEx(time.Millisecond)},
+		"getset":      {3, fakeGetSet},
+		"getdel":      {2, fakeGetDel},
+		"getex":       {-2, fakeGetEx},
+		"mget":        {-2, fakeMGet},
+		"mset":        {-3, fakeMSet},
+		"msetnx":      {-3, fakeMSetNX},
+		"incr":        {2, fakeIncrBy(1, false)},
+		"decr":        {2, fakeIncrBy(-1, false)},
+		"incrby":      {3, fakeIncrBy(1, true)},
+		"decrby":      {3, fakeIncrBy(-1, true)},
+		"incrbyfloat": {3, fakeIncrByFloat},
+		"append":      {3, fakeAppend},
+		"strlen":      {2, fakeStrLen},
+		"getrange":    {4, fakeGetRange},
+		"setrange":    {4, fakeSetRange},
+
+		// hashes
+		"hset":         {-4, fakeHSet(false)},
+		"hmset":        {-4, fakeHSet(true)},
+		"hsetnx":       {4, fakeHSetNX},
+		"hget":         {3, fakeHGet},
+		"hmget":        {-3, fakeHMGet},
+		"hgetall":      {2, fakeHGetAll},
+		"hdel":         {-3, fakeHDel},
+		"hexists":      {3, fakeHExists},
+		"hlen":         {2, fakeHLen},
+		"hkeys":        {2, fakeHKeys},
+		"hvals":        {2, fakeHVals},
+		"hincrby":      {4, fakeHIncrBy},
+		"hincrbyfloat": {4, fakeHIncrByFloat},
+
+		// lists
+		"lpush":     {-3, fakePush(true, false)},
+		"rpush":     {-3, fakePush(false, false)},
+		"lpushx":    {-3, fakePush(true, true)},
+		"rpushx":    {-3, fakePush(false, true)},
+		"lpop":      {-2, fakePop(true)},
+		"rpop":      {-2, fakePop(false)},
+		"llen":      {2, fakeLLen},
+		"lrange":    {4, fakeLRange},
+		"lindex":    {3, fakeLIndex},
+		"lset":      {4, fakeLSet},
+		"lrem":      {4, fakeLRem},
+		"ltrim":     {4, fakeLTrim},
+		"linsert":   {5, fakeLInsert},
+		"lpos":      {-3, fakeLPos},
+		"rpoplpush": {3, fakeRPopLPush},
+		"lmove":     {5, fakeLMove},
+
+		// sets
+		"sadd":        {-3, fakeSAdd},
+		"srem":        {-3, fakeSRem},
+		"smembers":    {2, fakeSMembers},
+		"sismember":   {3, fakeSIsMember},
+		"smismember":  {-3, fakeSMIsMember},
+		"scard":       {2, fakeSCard},
+		"spop":        {-2, fakeSPop},
+		"srandmember": {-2, fakeSRandMember},
+		"smove":       {4, fakeSMove},
+		"sinter":      {-2, fakeSetOp("inter", false)},
+		"sunion":      {-2, fakeSetOp("union", false)},
+		"sdiff":       {-2, fakeSetOp("diff", false)},
+		"sinterstore": {-3, fakeSetOp("inter", true)},
+		"sunionstore": {-3, fakeSetOp("union", true)},
+		"sdiffstore":  {-3, fakeSetOp("diff", true)},
+
+		// sorted sets
+		"zadd":             {-4, fakeZAdd},
+		"zincrby":          {4, fakeZIncrBy},
+		"zrem":             {-3, fakeZRem},
+		"zscore":           {3, fakeZScore},
+		"zmscore":          {-3, fakeZMScore},
+		"zcard":            {2, fakeZCard},
+		"zcount":           {4, fakeZCount},
+		"zrank":            {3, fakeZRank(false)},
+		"zrevrank":         {3, fakeZRank(true)},
+		"zrange":           {-4, fakeZRange},
+		"zrevrange":        {-4, fakeZRangeCompat("rev")},
+		"zrangebyscore":    {-4, fakeZRangeCompat("byscore")},
+		"zrevrangebyscore": {-4, fakeZRangeCompat("byscore", "rev")},
+		"zrangebylex":      {-4, fakeZRangeCompat("bylex")},
+		"zrevrangebylex":   {-4, fakeZRangeCompat("bylex", "rev")},
+		"zremrangebyrank":  {4, fakeZRemRangeByRank},
+		"zremrangebyscore": {4, fakeZRemRangeByScore},
+		"zpopmin":          {-2, fakeZPop(false)},
+		"zpopmax":          {-2, fakeZPop(true)},
+	}
+}
+
+func fakeOK(_ *fakeStore, _ []string) interface{} {
+	return respStatus("OK")
+}
+
+func fakePing(_ *fakeStore, args []string) interface{} {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return respStatus("PONG")
+}
+
+// fakeConfig keeps the parameters of CONFIG SET, notify-keyspace-events is the one of the mock.
+func fakeConfig(s *fakeStore, args []string) interface{} {
+	switch strings.ToLower(args[0]) {
+	case "set":
+		if len(args) < 3 || len(args)%2 == 0 {
+			return errArity("config|set")
+		}
+		for i := 1; i < len(args); i += 2 {
+			param := strings.ToLower(args[i])
+			if param == "notify-keyspace-events" && s.notifier != nil {
+				if s.notifier.setFlags(args[i+1]) != nil {
+					return respError("ERR Invalid argument '" + args[i+1] + "' for CONFIG SET '" + args[i] + "'")
+				}
+				continue
+			}
+			s.config[param] = args[i+1]
+		}
+		return respStatus("OK")
+	case "get":
+		if len(args) < 2 {
+			return errArity("config|get")
+		}
+		params := map[string]string{}
+		for key, val := range s.config {
+			params[key] = val
+		}
+		if s.notifier != nil {
+			params["notify-keyspace-events"] = s.notifier.getFlags()
+		}
+		keys := make([]string, 0, len(params))
+		for key := range params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		vals := []interface{}{}
+		for _, key := range keys {
+			for _, pattern := range args[1:] {
+				if globMatch(strings.ToLower(pattern), key) {
+					vals = append(vals, key, params[key])
+					break
+				}
+			}
+		}
+		return respMap(vals)
+	}
+	return respError("ERR unknown subcommand '" + args[0] + "'")
+}
+
+//------------------------------------------------------------------------------
+// keys
+
+func fakeDel(s *fakeStore, args []string) interface{} {
+	var n int64
+	for _, key := range args {
+		if s.del(key) {
+			s.notify("del", key)
+			n++
+		}
+	}
+	return n
+}
+
+func fakeExists(s *fakeStore, args []string) interface{} {
+	var n int64
+	for _, key := range args {
+		if s.get(key) != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func fakeType(s *fakeStore, args []string) interface{} {
+	e := s.get(args[0])
+	if e == nil {
+		return respStatus("none")
+	}
+	return respStatus(e.typ())
+}
+
+func fakeKeys(s *fakeStore, args []string) interface{} {
+	keys := []string{}
+	for _, key := range s.sortedKeys() {
+		if globMatch(args[0], key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// fakeScan uses the offset in the sorted keys as the cursor.
+func fakeScan(s *fakeStore, args []string) interface{} {
+	cursor, err := strconv.Atoi(args[0])
+	if err != nil || cursor < 0 {
+		return respError("ERR invalid cursor")
+	}
+	match, count, keyType := "*", 10, ""
+	for i := 1; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return errSyntax
+		}
+		switch strings.ToLower(args[i]) {
+		case "match":
+			match = args[i+1]
+		case "count":
+			if count, err = strconv.Atoi(args[i+1]); err != nil || count <= 0 {
+				return errSyntax
+			}
+		case "type":
+			keyType = strings.ToLower(args[i+1])
+		default:
+			return errSyntax
+		}
+	}
+
+	keys := s.sortedKeys()
+	page := []string{}
+	next := cursor + count
+	if next >= len(keys) {
+		next = 0
+	}
+	for i := cursor; i < len(keys) && i < cursor+count; i++ {
+		if !globMatch(match, keys[i]) {
+			continue
+		}
+		if keyType != "" && s.get(keys[i]).typ() != keyType {
+			continue
+		}
+		page = append(page, keys[i])
+	}
+	return []interface{}{strconv.Itoa(next), page}
+}
+
+func fakeFlush(s *fakeStore, _ []string) interface{} {
+	s.keys = make(map[string]*fakeEntry)
+	return respStatus("OK")
+}
+
+func fakeRename(nx bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		e := s.get(args[0])
+		if e == nil {
+			return errNoSuchKey
+		}
+		if nx && s.get(args[1]) != nil {
+			return int64(0)
+		}
+		delete(s.keys, args[0])
+		s.keys[args[1]] = e
+		s.notify("rename_from", args[0])
+		s.notify("rename_to", args[1])
+		if nx {
+			return int64(1)
+		}
+		return respStatus("OK")
+	}
+}
+
+func fakeRandomKey(s *fakeStore, _ []string) interface{} {
+	keys := s.sortedKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+	return keys[rand.Intn(len(keys))]
+}
+
+func fakeExpire(unit time.Duration, at bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errNotInt
+		}
+		var flag string
+		if len(args) > 2 {
+			flag = strings.ToLower(args[2])
+		}
+
+		e := s.get(args[0])
+		if e == nil {
+			return int64(0)
+		}
+
+		var expireAt time.Time
+		if at {
+			expireAt = time.Unix(0, 0).Add(time.Duration(n) * unit)
+		} else {
+			expireAt = s.now().Add(time.Duration(n) * unit)
+		}
+
+		switch flag {
+		case "":
+		case "nx":
+			if !e.expireAt.IsZero() {
+				return int64(0)
+			}
+		case "xx":
+			if e.expireAt.IsZero() {
+				return int64(0)
+			}
+		case "gt":
+			if e.expireAt.IsZero() || !expireAt.After(e.expireAt) {
+				return int64(0)
+			}
+		case "lt":
+			if !e.expireAt.IsZero() && !expireAt.Before(e.expireAt) {
+				return int64(0)
+			}
+		default:
+			return respError("ERR Unsupported option " + args[2])
+		}
+
+		e.expireAt = expireAt
+		if s.get(args[0]) == nil {
+			// a time in the past deletes the key
+			s.notify("del", args[0])
+		} else {
+			s.notify("expire", args[0])
+		}
+		return int64(1)
+	}
+}
+
+func fakeTTL(unit time.Duration, at bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		e := s.get(args[0])
+		switch {
+		case e == nil:
+			return int64(-2)
+		case e.expireAt.IsZero():
+			return int64(-1)
+		case at:
+			return e.expireAt.UnixNano() / int64(unit)
+		}
+		ttl := e.expireAt.Sub(s.now())
+		return int64((ttl + unit/2) / unit)
+	}
+}
+
+func fakePersist(s *fakeStore, args []string) interface{} {
+	e := s.get(args[0])
+	if e == nil || e.expireAt.IsZero() {
+		return int64(0)
+	}
+	e.expireAt = time.Time{}
+	return int64(1)
+}
+
+//------------------------------------------------------------------------------
+// strings
+
+func fakeGet(s *fakeStore, args []string) interface{} {
+	v, ok, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+// fakeSet implements SET key value [NX|XX] [GET] [EX|PX|EXAT|PXAT n|KEEPTTL].
+func fakeSet(s *fakeStore, args []string) interface{} {
+	key, val := args[0], args[1]
+	var (
+		nx, xx, get, keepTTL bool
+		expireAt             time.Time
+	)
+	for i := 2; i < len(args); i++ {
+		switch opt := strings.ToLower(args[i]); opt {
+		case "nx":
+			nx = true
+		case "xx":
+			xx = true
+		case "get":
+			get = true
+		case "keepttl":
+			keepTTL = true
+		case "ex", "px", "exat", "pxat":
+			if i+1 >= len(args) {
+				return errSyntax
+			}
+			i++
+			n, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return errNotInt
+			}
+			if n <= 0 {
+				return respError("ERR invalid expire time in 'set' command")
+			}
+			switch opt {
+			case "ex":
+				expireAt = s.now().Add(time.Duration(n) * time.Second)
+			case "px":
+				expireAt = s.now().Add(time.Duration(n) * time.Millisecond)
+			case "exat":
+				expireAt = time.Unix(n, 0)
+			case "pxat":
+				expireAt = time.UnixMilli(n)
+			}
+		default:
+			return errSyntax
+		}
+	}
+	if nx && xx {
+		return errSyntax
+	}
+
+	old, exists, err := s.getString(key)
+	if err != nil && (get || !nx && !xx) {
+		if get {
+			return err
+		}
+	}
+	e := s.get(key)
+	if (nx && e != nil) || (xx && e == nil) {
+		if get && exists {
+			return old
+		}
+		return nil
+	}
+
+	if keepTTL && e != nil {
+		expireAt = e.expireAt
+	}
+	s.set(key, val).expireAt = expireAt
+
+	if get {
+		if !exists {
+			return nil
+		}
+		return old
+	}
+	return respStatus("OK")
+}
+
+func fakeSetNX(s *fakeStore, args []string) interface{} {
+	if s.get(args[0]) != nil {
+		return int64(0)
+	}
+	s.set(args[0], args[1])
+	return int64(1)
+}
+
+func fakeSetEx(unit time.Duration) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errNotInt
+		}
+		if n <= 0 {
+			return respError("ERR invalid expire time")
+		}
+		s.set(args[0], args[2]).expireAt = s.now().Add(time.Duration(n) * unit)
+		return respStatus("OK")
+	}
+}
+
+func fakeGetSet(s *fakeStore, args []string) interface{} {
+	old, ok, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	s.set(args[0], args[1])
+	if !ok {
+		return nil
+	}
+	return old
+}
+
+func fakeGetDel(s *fakeStore, args []string) interface{} {
+	v, ok, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	delete(s.keys, args[0])
+	return v
+}
+
+func fakeGetEx(s *fakeStore, args []string) interface{} {
+	v, ok, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	e := s.get(args[0])
+	for i := 1; i < len(args); i++ {
+		opt := strings.ToLower(args[i])
+		if opt == "persist" {
+			e.expireAt = time.Time{}
+			continue
+		}
+		if i+1 >= len(args) {
+			return errSyntax
+		}
+		i++
+		n, err := strconv.ParseInt(args[i], 10, 64)
+		if err != nil {
+			return errNotInt
+		}
+		switch opt {
+		case "ex":
+			e.expireAt = s.now().Add(time.Duration(n) * time.Second)
+		case "px":
+			e.expireAt = s.now().Add(time.Duration(n) * time.Millisecond)
+		case "exat":
+			e.expireAt = time.Unix(n, 0)
+		case "pxat":
+			e.expireAt = time.UnixMilli(n)
+		default:
+			return errSyntax
+		}
+	}
+	return v
+}
+
+func fakeMGet(s *fakeStore, args []string) interface{} {
+	vals := make([]interface{}, len(args))
+	for i, key := range args {
+		if v, ok, err := s.getString(key); err == nil && ok {
+			vals[i] = v
+		}
+	}
+	return vals
+}
+
+func fakeMSet(s *fakeStore, args []string) interface{} {
+	if len(args)%2 != 0 {
+		return errArity("mset")
+	}
+	for i := 0; i < len(args); i += 2 {
+		s.set(args[i], args[i+1])
+	}
+	return respStatus("OK")
+}
+
+func fakeMSetNX(s *fakeStore, args []string) interface{} {
+	if len(args)%2 != 0 {
+		return errArity("msetnx")
+	}
+	for i := 0; i < len(args); i += 2 {
+		if s.get(args[i]) != nil {
+			return int64(0)
+		}
+	}
+	for i := 0; i < len(args); i += 2 {
+		s.set(args[i], args[i+1])
+	}
+	return int64(1)
+}
+
+// update replaces the value of a string key, the TTL is kept.
+func (s *fakeStore) update(key, val string) {
+	if e := s.get(key); e != nil {
+		e.val = val
+		return
+	}
+	s.set(key, val)
+}
+
+func fakeIncrBy(sign int64, by bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		incr := sign
+		if by {
+			n, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return errNotInt
+			}
+			incr *= n
+		}
+
+		v, ok, err := s.getString(args[0])
+		if err != nil {
+			return err
+		}
+		var n int64
+		if ok {
+			if n, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return errNotInt
+			}
+		}
+		if (incr > 0 && n > math.MaxInt64-incr) || (incr < 0 && n < math.MinInt64-incr) {
+			return errOverflow
+		}
+		n += incr
+		s.update(args[0], strconv.FormatInt(n, 10))
+		return n
+	}
+}
+
+func fakeIncrByFloat(s *fakeStore, args []string) interface{} {
+	incr, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return errNotFloat
+	}
+	v, ok, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	var f float64
+	if ok {
+		if f, err = strconv.ParseFloat(v, 64); err != nil {
+			return errNotFloat
+		}
+	}
+	f += incr
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return respError("ERR increment would produce NaN or Infinity")
+	}
+	res := formatFloat(f)
+	s.update(args[0], res)
+	return res
+}
+
+func fakeAppend(s *fakeStore, args []string) interface{} {
+	v, _, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	v += args[1]
+	s.update(args[0], v)
+	return int64(len(v))
+}
+
+func fakeStrLen(s *fakeStore, args []string) interface{} {
+	v, _, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	return int64(len(v))
+}
+
+func fakeGetRange(s *fakeStore, args []string) interface{} {
+	start, err1 := strconv.Atoi(args[1])
+	end, err2 := strconv.Atoi(args[2])
+	if err1 != nil || err2 != nil {
+		return errNotInt
+	}
+	v, _, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	start, end, ok := normalizeRange(start, end, len(v))
+	if !ok {
+		return ""
+	}
+	return v[start : end+1]
+}
+
+func fakeSetRange(s *fakeStore, args []string) interface{} {
+	offset, err := strconv.Atoi(args[1])
+	if err != nil || offset < 0 {
+		return errOutOfRange
+	}
+	v, _, err := s.getString(args[0])
+	if err != nil {
+		return err
+	}
+	if args[2] == "" {
+		return int64(len(v))
+	}
+	b := []byte(v)
+	if need := offset + len(args[2]); need > len(b) {
+		b = append(b, make([]byte, need-len(b))...)
+	}
+	copy(b[offset:], args[2])
+	s.update(args[0], string(b))
+	return int64(len(b))
+}
+
+// normalizeRange converts Redis inclusive indexes, negative from the end, into valid ones.
+func normalizeRange(start, end, n int) (int, int, bool) {
+	if start < 0 {
+		start += n
+	}
+	if end < 0 {
+		end += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end >= n {
+		end = n - 1
+	}
+	if start > end || start >= n {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+//------------------------------------------------------------------------------
+// hashes
+
+func fakeHSet(hmset bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		if len(args)%2 != 1 {
+			if hmset {
+				return errArity("hmset")
+			}
+			return errArity("hset")
+		}
+		h, err := s.getHash(args[0], true)
+		if err != nil {
+			return err
+		}
+		var n int64
+		for i := 1; i < len(args); i += 2 {
+			if _, ok := h[args[i]]; !ok {
+				n++
+			}
+			h[args[i]] = args[i+1]
+		}
+		if hmset {
+			return respStatus("OK")
+		}
+		return n
+	}
+}
+
+func fakeHSetNX(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], true)
+	if err != nil {
+		return err
+	}
+	if _, ok := h[args[1]]; ok {
+		return int64(0)
+	}
+	h[args[1]] = args[2]
+	return int64(1)
+}
+
+func fakeHGet(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	v, ok := h[args[1]]
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func fakeHMGet(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	vals := make([]interface{}, len(args)-1)
+	for i, field := range args[1:] {
+		if v, ok := h[field]; ok {
+			vals[i] = v
+		}
+	}
+	return vals
+}
+
+func fakeHGetAll(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	vals := respMap{}
+	for _, field := range sortedFields(h) {
+		vals = append(vals, field, h[field])
+	}
+	return vals
+}
+
+func fakeHDel(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	var n int64
+	for _, field := range args[1:] {
+		if _, ok := h[field]; ok {
+			delete(h, field)
+			n++
+		}
+	}
+	s.cleanup(args[0])
+	return n
+}
+
+func fakeHExists(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	_, ok := h[args[1]]
+	return ok
+}
+
+func fakeHLen(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	return int64(len(h))
+}
+
+func fakeHKeys(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	return sortedFields(h)
+}
+
+func fakeHVals(s *fakeStore, args []string) interface{} {
+	h, err := s.getHash(args[0], false)
+	if err != nil {
+		return err
+	}
+	vals := []string{}
+	for _, field := range sortedFields(h) {
+		vals = append(vals, h[field])
+	}
+	return vals
+}
+
+func fakeHIncrBy(s *fakeStore, args []string) interface{} {
+	incr, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return errNotInt
+	}
+	h, err := s.getHash(args[0], true)
+	if err != nil {
+		return err
+	}
+	var n int64
+	if v, ok := h[args[1]]; ok {
+		if n, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return respError("ERR hash value is not an integer")
+		}
+	}
+	n += incr
+	h[args[1]] = strconv.FormatInt(n, 10)
+	return n
+}
+
+func fakeHIncrByFloat(s *fakeStore, args []string) interface{} {
+	incr, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return errNotFloat
+	}
+	h, err := s.getHash(args[0], true)
+	if err != nil {
+		return err
+	}
+	var f float64
+	if v, ok := h[args[1]]; ok {
+		if f, err = strconv.ParseFloat(v, 64); err != nil {
+			return respError("ERR hash value is not a float")
+		}
+	}
+	res := formatFloat(f + incr)
+	h[args[1]] = res
+	return res
+}
+
+func sortedFields(h map[string]string) []string {
+	fields := make([]string, 0, len(h))
+	for field := range h {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+//------------------------------------------------------------------------------
+// lists
+
+func fakePush(left, exists bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		l, err := s.getList(args[0], !exists)
+		if err != nil {
+			return err
+		}
+		if l == nil {
+			return int64(0)
+		}
+		for _, v := range args[1:] {
+			if left {
+				l.items = append([]string{v}, l.items...)
+			} else {
+				l.items = append(l.items, v)
+			}
+		}
+		return int64(len(l.items))
+	}
+}
+
+func fakePop(left bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		count := -1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 0 {
+				return respError("ERR value is out of range, must be positive")
+			}
+			count = n
+		}
+		l, err := s.getList(args[0], false)
+		if err != nil {
+			return err
+		}
+		if l == nil {
+			if count >= 0 {
+				return respNilArr{}
+			}
+			return nil
+		}
+
+		n := count
+		if n < 0 {
+			n = 1
+		}
+		if n > len(l.items) {
+			n = len(l.items)
+		}
+		var popped []string
+		if left {
+			popped = append(popped, l.items[:n]...)
+			l.items = l.items[n:]
+		} else {
+			for i := 0; i < n; i++ {
+				popped = append(popped, l.items[len(l.items)-1-i])
+			}
+			l.items = l.items[:len(l.items)-n]
+		}
+		s.cleanup(args[0])
+
+		if count < 0 {
+			return popped[0]
+		}
+		return popped
+	}
+}
+
+func fakeLLen(s *fakeStore, args []string) interface{} {
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return int64(0)
+	}
+	return int64(len(l.items))
+}
+
+func fakeLRange(s *fakeStore, args []string) interface{} {
+	start, err1 := strconv.Atoi(args[1])
+	stop, err2 := strconv.Atoi(args[2])
+	if err1 != nil || err2 != nil {
+		return errNotInt
+	}
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return []string{}
+	}
+	start, stop, ok := normalizeRange(start, stop, len(l.items))
+	if !ok {
+		return []string{}
+	}
+	return append([]string{}, l.items[start:stop+1]...)
+}
+
+func fakeLIndex(s *fakeStore, args []string) interface{} {
+	i, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errNotInt
+	}
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return nil
+	}
+	if i < 0 {
+		i += len(l.items)
+	}
+	if i < 0 || i >= len(l.items) {
+		return nil
+	}
+	return l.items[i]
+}
+
+func fakeLSet(s *fakeStore, args []string) interface{} {
+	i, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errNotInt
+	}
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return errNoSuchKey
+	}
+	if i < 0 {
+		i += len(l.items)
+	}
+	if i < 0 || i >= len(l.items) {
+		return errOutOfRange
+	}
+	l.items[i] = args[2]
+	return respStatus("OK")
+}
+
+func fakeLRem(s *fakeStore, args []string) interface{} {
+	count, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errNotInt
+	}
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return int64(0)
+	}
+
+	var (
+		n     int64
+		items = l.items
+		keep  = make([]bool, len(items))
+	)
+	for i := range keep {
+		keep[i] = true
+	}
+	if count >= 0 {
+		for i := 0; i < len(items) && (count == 0 || n < int64(count)); i++ {
+			if items[i] == args[2] {
+				keep[i] = false
+				n++
+			}
+		}
+	} else {
+		for i := len(items) - 1; i >= 0 && n < int64(-count); i-- {
+			if items[i] == args[2] {
+				keep[i] = false
+				n++
+			}
+		}
+	}
+	var rest []string
+	for i, v := range items {
+		if keep[i] {
+			rest = append(rest, v)
+		}
+	}
+	l.items = rest
+	s.cleanup(args[0])
+	return n
+}
+
+func fakeLTrim(s *fakeStore, args []string) interface{} {
+	start, err1 := strconv.Atoi(args[1])
+	stop, err2 := strconv.Atoi(args[2])
+	if err1 != nil || err2 != nil {
+		return errNotInt
+	}
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return respStatus("OK")
+	}
+	start, stop, ok := normalizeRange(start, stop, len(l.items))
+	if ok {
+		l.items = append([]string{}, l.items[start:stop+1]...)
+	} else {
+		l.items = nil
+	}
+	s.cleanup(args[0])
+	return respStatus("OK")
+}
+
+func fakeLInsert(s *fakeStore, args []string) interface{} {
+	where := strings.ToLower(args[1])
+	if where != "before" && where != "after" {
+		return errSyntax
+	}
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return int64(0)
+	}
+	for i, v := range l.items {
+		if v != args[2] {
+			continue
+		}
+		if where == "after" {
+			i++
+		}
+		l.items = append(l.items[:i], append([]string{args[3]}, l.items[i:]...)...)
+		return int64(len(l.items))
+	}
+	return int64(-1)
+}
+
+// fakeLPos implements LPOS key element [RANK rank] [COUNT count] [MAXLEN len].
+func fakeLPos(s *fakeStore, args []string) interface{} {
+	rank, count, maxLen := 1, -1, 0
+	for i := 2; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return errSyntax
+		}
+		n, err := strconv.Atoi(args[i+1])
+		if err != nil {
+			return errNotInt
+		}
+		switch strings.ToLower(args[i]) {
+		case "rank":
+			if n == 0 {
+				return respError("ERR RANK can't be zero")
+			}
+			rank = n
+		case "count":
+			count = n
+		case "maxlen":
+			maxLen = n
+		default:
+			return errSyntax
+		}
+	}
+
+	l, err := s.getList(args[0], false)
+	if err != nil {
+		return err
+	}
+	var items []string
+	if l != nil {
+		items = l.items
+	}
+
+	var found []int64
+	skip := rank
+	if skip < 0 {
+		skip = -skip
+	}
+	for j := 0; j < len(items) && (maxLen == 0 || j < maxLen); j++ {
+		i := j
+		if rank < 0 {
+			i = len(items) - 1 - j
+		}
+		if items[i] != args[1] {
+			continue
+		}
+		if skip--; skip > 0 {
+			continue
+		}
+		found = append(found, int64(i))
+		if count < 0 || (count > 0 && len(found) == count) {
+			break
+		}
+		skip = 1
+	}
+
+	if count >= 0 {
+		vals := make([]interface{}, len(found))
+		for i, v := range found {
+			vals[i] = v
+		}
+		return vals
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+func fakeRPopLPush(s *fakeStore, args []string) interface{} {
+	return fakeMove(s, args[0], args[1], false, true)
+}
+
+func fakeLMove(s *fakeStore, args []string) interface{} {
+	from, to := strings.ToLower(args[2]), strings.ToLower(args[3])
+	if (from != "left" && from != "right") || (to != "left" && to != "right") {
+		return errSyntax
+	}
+	return fakeMove(s, args[0], args[1], from == "left", to == "left")
+}
+
+func fakeMove(s *fakeStore, source, destination string, fromLeft, toLeft bool) interface{} {
+	src, err := s.getList(source, false)
+	if err != nil {
+		return err
+	}
+	if src == nil {
+		return nil
+	}
+	if _, err = s.getList(destination, false); err != nil {
+		return err
+	}
+
+	var v string
+	if fromLeft {
+		v, src.items = src.items[0], src.items[1:]
+	} else {
+		v, src.items = src.items[len(src.items)-1], src.items[:len(src.items)-1]
+	}
+	pop, push := "rpop", "rpush"
+	if fromLeft {
+		pop = "lpop"
+	}
+	if toLeft {
+		push = "lpush"
+	}
+	s.notify(pop, source)
+	s.cleanup(source)
+
+	dst, _ := s.getList(destination, true)
+	if toLeft {
+		dst.items = append([]string{v}, dst.items...)
+	} else {
+		dst.items = append(dst.items, v)
+	}
+	s.notify(push, destination)
+	return v
+}
+
+//------------------------------------------------------------------------------
+// sets
+
+func fakeSAdd(s *fakeStore, args []string) interface{} {
+	set, err := s.getSet(args[0], true)
+	if err != nil {
+		return err
+	}
+	var n int64
+	for _, m := range args[1:] {
+		if _, ok := set[m]; !ok {
+			set[m] = struct{}{}
+			n++
+		}
+	}
+	return n
+}
+
+func fakeSRem(s *fakeStore, args []string) interface{} {
+	set, err := s.getSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	var n int64
+	for _, m := range args[1:] {
+		if _, ok := set[m]; ok {
+			delete(set, m)
+			n++
+		}
+	}
+	s.cleanup(args[0])
+	return n
+}
+
+func fakeSMembers(s *fakeStore, args []string) interface{} {
+	set, err := s.getSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	return sortedMembers(set)
+}
+
+func fakeSIsMember(s *fakeStore, args []string) interface{} {
+	set, err := s.getSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	_, ok := set[args[1]]
+	return ok
+}
+
+func fakeSMIsMember(s *fakeStore, args []string) interface{} {
+	set, err := s.getSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	vals := make([]interface{}, len(args)-1)
+	for i, m := range args[1:] {
+		_, ok := set[m]
+		vals[i] = ok
+	}
+	return vals
+}
+
+func fakeSCard(s *fakeStore, args []string) interface{} {
+	set, err := s.getSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	return int64(len(set))
+}
+
+func fakeSPop(s *fakeStore, args []string) interface{} {
+	res := fakeSRandMember(s, args)
+	if err, ok := res.(error); ok {
+		return err
+	}
+	set, _ := s.getSet(args[0], false)
+	switch v := res.(type) {
+	case string:
+		delete(set, v)
+	case []string:
+		for _, m := range v {
+			delete(set, m)
+		}
+	}
+	s.cleanup(args[0])
+	return res
+}
+
+func fakeSRandMember(s *fakeStore, args []string) interface{} {
+	count, withCount := 1, len(args) > 1
+	if withCount {
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return errNotInt
+		}
+		count = n
+	}
+	set, err := s.getSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	members := sortedMembers(set)
+	rand.Shuffle(len(members), func(i, j int) {
+		members[i], members[j] = members[j], members[i]
+	})
+	if !withCount {
+		if len(members) == 0 {
+			return nil
+		}
+		return members[0]
+	}
+	if count < 0 {
+		// repeated members are allowed with a negative count
+		res := make([]string, 0, -count)
+		for i := 0; i < -count && len(members) > 0; i++ {
+			res = append(res, members[rand.Intn(len(members))])
+		}
+		return res
+	}
+	if count > len(members) {
+		count = len(members)
+	}
+	return members[:count]
+}
+
+func fakeSMove(s *fakeStore, args []string) interface{} {
+	src, err := s.getSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	if _, err = s.getSet(args[1], false); err != nil {
+		return err
+	}
+	if _, ok := src[args[2]]; !ok {
+		return int64(0)
+	}
+	delete(src, args[2])
+	s.notify("srem", args[0])
+	s.cleanup(args[0])
+	dst, _ := s.getSet(args[1], true)
+	dst[args[2]] = struct{}{}
+	s.notify("sadd", args[1])
+	return int64(1)
+}
+
+func fakeSetOp(op string, store bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		keys := args
+		if store {
+			keys = args[1:]
+		}
+
+		var res map[string]struct{}
+		for i, key := range keys {
+			set, err := s.getSet(key, false)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				res = make(map[string]struct{}, len(set))
+				for m := range set {
+					res[m] = struct{}{}
+				}
+				continue
+			}
+			switch op {
+			case "inter":
+				for m := range res {
+					if _, ok := set[m]; !ok {
+						delete(res, m)
+					}
+				}
+			case "union":
+				for m := range set {
+					res[m] = struct{}{}
+				}
+			case "diff":
+				for m := range set {
+					delete(res, m)
+				}
+			}
+		}
+
+		if !store {
+			return sortedMembers(res)
+		}
+		delete(s.keys, args[0])
+		if len(res) > 0 {
+			s.set(args[0], res)
+		}
+		return int64(len(res))
+	}
+}
+
+func sortedMembers(set map[string]struct{}) []string {
+	members := make([]string, 0, len(set))
+	for m := range set {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	return members
+}
+
+//------------------------------------------------------------------------------
+// sorted sets
+
+type fakeZMember struct {
+	member string
+	score  float64
+}
+
+// sortedZSet returns the members ordered by score, then lexicographically.
+func sortedZSet(z map[string]float64) []fakeZMember {
+	members := make([]fakeZMember, 0, len(z))
+	for m, score := range z {
+		members = append(members, fakeZMember{member: m, score: score})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].score != members[j].score {
+			return members[i].score < members[j].score
+		}
+		return members[i].member < members[j].member
+	})
+	return members
+}
+
+// fakeZAdd implements ZADD key [NX|XX] [GT|LT] [CH] [INCR] score member [score member ...].
+func fakeZAdd(s *fakeStore, args []string) interface{} {
+	var nx, xx, gt, lt, ch, incr bool
+	i := 1
+loop:
+	for ; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "nx":
+			nx = true
+		case "xx":
+			xx = true
+		case "gt":
+			gt = true
+		case "lt":
+			lt = true
+		case "ch":
+			ch = true
+		case "incr":
+			incr = true
+		default:
+			break loop
+		}
+	}
+	pairs := args[i:]
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return errSyntax
+	}
+	if (nx && xx) || (gt && lt) || (nx && (gt || lt)) {
+		return respError("ERR XX and NX options at the same time are not compatible")
+	}
+	if incr && len(pairs) != 2 {
+		return respError("ERR INCR option supports a single increment-element pair")
+	}
+
+	scores := make([]float64, len(pairs)/2)
+	for j := range scores {
+		f, err := parseScore(pairs[2*j])
+		if err != nil {
+			return errNotFloat
+		}
+		scores[j] = f
+	}
+
+	z, err := s.getZSet(args[0], !xx)
+	if err != nil {
+		return err
+	}
+	if z == nil {
+		if incr {
+			return nil
+		}
+		return int64(0)
+	}
+
+	var added, changed int64
+	for j, score := range scores {
+		member := pairs[2*j+1]
+		old, exists := z[member]
+		if (nx && exists) || (xx && !exists) {
+			if incr {
+				s.cleanup(args[0])
+				return nil
+			}
+			continue
+		}
+		if incr && exists {
+			score += old
+		}
+		if exists && ((gt && score <= old) || (lt && score >= old)) {
+			if incr {
+				return nil
+			}
+			continue
+		}
+		z[member] = score
+		if !exists {
+			added++
+		} else if old != score {
+			changed++
+		}
+		if incr {
+			return score
+		}
+	}
+	s.cleanup(args[0])
+	if ch {
+		return added + changed
+	}
+	return added
+}
+
+func fakeZIncrBy(s *fakeStore, args []string) interface{} {
+	incr, err := parseScore(args[1])
+	if err != nil {
+		return errNotFloat
+	}
+	z, err := s.getZSet(args[0], true)
+	if err != nil {
+		return err
+	}
+	z[args[2]] += incr
+	return z[args[2]]
+}
+
+func fakeZRem(s *fakeStore, args []string) interface{} {
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	var n int64
+	for _, m := range args[1:] {
+		if _, ok := z[m]; ok {
+			delete(z, m)
+			n++
+		}
+	}
+	s.cleanup(args[0])
+	return n
+}
+
+func fakeZScore(s *fakeStore, args []string) interface{} {
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	score, ok := z[args[1]]
+	if !ok {
+		return nil
+	}
+	return score
+}
+
+func fakeZMScore(s *fakeStore, args []string) interface{} {
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	vals := make([]interface{}, len(args)-1)
+	for i, m := range args[1:] {
+		if score, ok := z[m]; ok {
+			vals[i] = score
+		}
+	}
+	return vals
+}
+
+func fakeZCard(s *fakeStore, args []string) interface{} {
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	return int64(len(z))
+}
+
+func fakeZCount(s *fakeStore, args []string) interface{} {
+	min, max, err := parseScoreRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	var n int64
+	for _, score := range z {
+		if min.below(score) && max.above(score) {
+			n++
+		}
+	}
+	return n
+}
+
+func fakeZRank(rev bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		z, err := s.getZSet(args[0], false)
+		if err != nil {
+			return err
+		}
+		members := sortedZSet(z)
+		for i, m := range members {
+			if m.member != args[1] {
+				continue
+			}
+			if rev {
+				i = len(members) - 1 - i
+			}
+			return int64(i)
+		}
+		return nil
+	}
+}
+
+// fakeZRangeCompat rewrites the legacy ZRANGE commands into ZRANGE options.
+func fakeZRangeCompat(opts ...string) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		full := append([]string{}, args[:3]...)
+		rev := false
+		for _, opt := range opts {
+			rev = rev || opt == "rev"
+		}
+		if rev && (opts[0] == "byscore" || opts[0] == "bylex") {
+			// the legacy reverse commands take max before min
+			full[1], full[2] = args[2], args[1]
+		}
+		full = append(full, opts...)
+		full = append(full, args[3:]...)
+		return fakeZRange(s, full)
+	}
+}
+
+// fakeZRange implements ZRANGE key start stop [BYSCORE|BYLEX] [REV] [LIMIT offset count] [WITHSCORES].
+func fakeZRange(s *fakeStore, args []string) interface{} {
+	var (
+		byScore, byLex, rev, withScores bool
+		offset, count                   = 0, -1
+	)
+	for i := 3; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "byscore":
+			byScore = true
+		case "bylex":
+			byLex = true
+		case "rev":
+			rev = true
+		case "withscores":
+			withScores = true
+		case "limit":
+			if i+2 >= len(args) {
+				return errSyntax
+			}
+			var err1, err2 error
+			offset, err1 = strconv.Atoi(args[i+1])
+			count, err2 = strconv.Atoi(args[i+2])
+			if err1 != nil || err2 != nil {
+				return errNotInt
+			}
+			i += 2
+		default:
+			return errSyntax
+		}
+	}
+
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	members := sortedZSet(z)
+	if rev {
+		for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+			members[i], members[j] = members[j], members[i]
+		}
+	}
+
+	var res []fakeZMember
+	switch {
+	case byScore:
+		min, max, err := parseScoreRange(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		if rev {
+			min, max, err = parseScoreRange(args[2], args[1])
+			if err != nil {
+				return err
+			}
+		}
+		for _, m := range members {
+			if min.below(m.score) && max.above(m.score) {
+				res = append(res, m)
+			}
+		}
+	case byLex:
+		min, max := args[1], args[2]
+		if rev {
+			min, max = args[2], args[1]
+		}
+		for _, m := range members {
+			ok, err := lexInRange(m.member, min, max)
+			if err != nil {
+				return err
+			}
+			if ok {
+				res = append(res, m)
+			}
+		}
+	default:
+		start, err1 := strconv.Atoi(args[1])
+		stop, err2 := strconv.Atoi(args[2])
+		if err1 != nil || err2 != nil {
+			return errNotInt
+		}
+		start, stop, ok := normalizeRange(start, stop, len(members))
+		if ok {
+			res = members[start : stop+1]
+		}
+	}
+
+	if byScore || byLex {
+		if offset > len(res) || offset < 0 {
+			res = nil
+		} else {
+			res = res[offset:]
+		}
+		if count >= 0 && count < len(res) {
+			res = res[:count]
+		}
+	}
+
+	vals := []interface{}{}
+	for _, m := range res {
+		vals = append(vals, m.member)
+		if withScores {
+			vals = append(vals, m.score)
+		}
+	}
+	if withScores {
+		return respPairs(vals)
+	}
+	return vals
+}
+
+func fakeZRemRangeByRank(s *fakeStore, args []string) interface{} {
+	start, err1 := strconv.Atoi(args[1])
+	stop, err2 := strconv.Atoi(args[2])
+	if err1 != nil || err2 != nil {
+		return errNotInt
+	}
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	members := sortedZSet(z)
+	start, stop, ok := normalizeRange(start, stop, len(members))
+	if !ok {
+		return int64(0)
+	}
+	for _, m := range members[start : stop+1] {
+		delete(z, m.member)
+	}
+	s.cleanup(args[0])
+	return int64(stop - start + 1)
+}
+
+func fakeZRemRangeByScore(s *fakeStore, args []string) interface{} {
+	min, max, err := parseScoreRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	z, err := s.getZSet(args[0], false)
+	if err != nil {
+		return err
+	}
+	var n int64
+	for m, score := range z {
+		if min.below(score) && max.above(score) {
+			delete(z, m)
+			n++
+		}
+	}
+	s.cleanup(args[0])
+	return n
+}
+
+func fakeZPop(max bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		count := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 0 {
+				return respError("ERR value is out of range, must be positive")
+			}
+			count = n
+		}
+		z, err := s.getZSet(args[0], false)
+		if err != nil {
+			return err
+		}
+		members := sortedZSet(z)
+		if max {
+			for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+				members[i], members[j] = members[j], members[i]
+			}
+		}
+		if count > len(members) {
+			count = len(members)
+		}
+		vals := []interface{}{}
+		for _, m := range members[:count] {
+			delete(z, m.member)
+			vals = append(vals, m.member, m.score)
+		}
+		s.cleanup(args[0])
+		return vals
+	}
+}
+
+func parseScore(s string) (float64, error) {
+	switch strings.ToLower(s) {
+	case "+inf", "inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+type scoreBound struct {
+	score     float64
+	exclusive bool
+}
+
+// below reports whether the score is above the bound as a minimum.
+func (b scoreBound) below(score float64) bool {
+	if b.exclusive {
+		return score > b.score
+	}
+	return score >= b.score
+}
+
+// above reports whether the score is below the bound as a maximum.
+func (b scoreBound) above(score float64) bool {
+	if b.exclusive {
+		return score < b.score
+	}
+	return score <= b.score
+}
+
+func parseScoreRange(min, max string) (scoreBound, scoreBound, error) {
+	parse := func(s string) (scoreBound, error) {
+		var b scoreBound
+		if strings.HasPrefix(s, "(") {
+			b.exclusive = true
+			s = s[1:]
+		}
+		f, err := parseScore(s)
+		if err != nil {
+			return b, errMinMaxFloat
+		}
+		b.score = f
+		return b, nil
+	}
+	lo, err := parse(min)
+	if err != nil {
+		return lo, lo, err
+	}
+	hi, err := parse(max)
+	return lo, hi, err
+}
+
+func lexInRange(member, min, max string) (bool, error) {
+	check := func(bound string, isMin bool) (bool, error) {
+		switch {
+		case bound == "-":
+			return isMin, nil
+		case bound == "+":
+			return !isMin, nil
+		case strings.HasPrefix(bound, "["):
+			if isMin {
+				return member >= bound[1:], nil
+			}
+			return member <= bound[1:], nil
+		case strings.HasPrefix(bound, "("):
+			if isMin {
+				return member > bound[1:], nil
+			}
+			return member < bound[1:], nil
+		}
+		return false, errMinMaxLex
+	}
+	lo, err := check(min, true)
+	if err != nil || !lo {
+		return false, err
+	}
+	return check(max, false)
+}
+
+//------------------------------------------------------------------------------
+
+// globMatch reports whether str matches the Redis glob-style pattern.
+func globMatch(pattern, str string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(str); i++ {
+				if globMatch(pattern[1:], str[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(str) == 0 {
+				return false
+			}
+			str = str[1:]
+			pattern = pattern[1:]
+		case '[':
+			if len(str) == 0 {
+				return false
+			}
+			end := strings.IndexByte(pattern[1:], ']')
+			if end < 0 {
+				// no closing bracket, match it literally
+				if str[0] != '[' {
+					return false
+				}
+				str, pattern = str[1:], pattern[1:]
+				continue
+			}
+			class := pattern[1 : end+1]
+			not := strings.HasPrefix(class, "^")
+			if not {
+				class = class[1:]
+			}
+			match := false
+			for i := 0; i < len(class); i++ {
+				if class[i] == '\\' && i+1 < len(class) {
+					i++
+					match = match || class[i] == str[0]
+				} else if i+2 < len(class) && class[i+1] == '-' {
+					lo, hi := class[i], class[i+2]
+					if lo > hi {
+						lo, hi = hi, lo
+					}
+					match = match || (str[0] >= lo && str[0] <= hi)
+					i += 2
+				} else {
+					match = match || class[i] == str[0]
+				}
+			}
+			if match == not {
+				return false
+			}
+			str = str[1:]
+			pattern = pattern[end+2:]
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(str) == 0 || str[0] != pattern[0] {
+				return false
+			}
+			str = str[1:]
+			pattern = pattern[1:]
+		}
+	}
+	return len(str) == 0
+}
+
+//------------------------------------------------------------------------------
+// keyspace events
+
+// fakeNotification is the keyspace event of a command, published for the keys it returns
+// from the arguments and the reply, the commands of several events notify them themselves.
+type fakeNotification struct {
+	event string
+	keys  func(args []string, reply interface{}) []string
+}
+
+var fakeNotifications = map[string]fakeNotification{
+	"set":              {"set", fakeSetEventKeys},
+	"setnx":            {"set", fakeChangedKey},
+	"setex":            {"set", fakeFirstKey},
+	"psetex":           {"set", fakeFirstKey},
+	"getset":           {"set", fakeWrittenKey},
+	"getdel":           {"del", fakeFirstKey},
+	"mset":             {"set", fakePairKeys},
+	"msetnx":           {"set", fakePairKeys},
+	"incr":             {"incrby", fakeFirstKey},
+	"decr":             {"incrby", fakeFirstKey},
+	"incrby":           {"incrby", fakeFirstKey},
+	"decrby":           {"incrby", fakeFirstKey},
+	"incrbyfloat":      {"incrbyfloat", fakeFirstKey},
+	"append":           {"append", fakeFirstKey},
+	"setrange":         {"setrange", fakeFirstKey},
+	"hset":             {"hset", fakeWrittenKey},
+	"hmset":            {"hset", fakeWrittenKey},
+	"hsetnx":           {"hset", fakeChangedKey},
+	"hdel":             {"hdel", fakeChangedKey},
+	"hincrby":          {"hincrby", fakeFirstKey},
+	"hincrbyfloat":     {"hincrbyfloat", fakeFirstKey},
+	"lpush":            {"lpush", fakeWrittenKey},
+	"rpush":            {"rpush", fakeWrittenKey},
+	"lpushx":           {"lpush", fakeChangedKey},
+	"rpushx":           {"rpush", fakeChangedKey},
+	"lpop":             {"lpop", fakeFirstKey},
+	"rpop":             {"rpop", fakeFirstKey},
+	"lset":             {"lset", fakeFirstKey},
+	"lrem":             {"lrem", fakeChangedKey},
+	"ltrim":            {"ltrim", fakeFirstKey},
+	"linsert":          {"linsert", fakeChangedKey},
+	"sadd":             {"sadd", fakeChangedKey},
+	"srem":             {"srem", fakeChangedKey},
+	"spop":             {"spop", fakeFirstKey},
+	"sinterstore":      {"sinterstore", fakeChangedKey},
+	"sunionstore":      {"sunionstore", fakeChangedKey},
+	"sdiffstore":       {"sdiffstore", fakeChangedKey},
+	"zadd":             {"zadd", fakeWrittenKey},
+	"zincrby":          {"zincr", fakeFirstKey},
+	"zrem":             {"zrem", fakeChangedKey},
+	"zremrangebyrank":  {"zremrangebyrank", fakeChangedKey},
+	"zremrangebyscore": {"zremrangebyscore", fakeChangedKey},
+	"zpopmin":          {"zpopmin", fakeFirstKey},
+	"zpopmax":          {"zpopmax", fakeFirstKey},
+	"persist":          {"persist", fakeChangedKey},
+	"xadd":             {"xadd", fakeFirstKey},
+	"xdel":             {"xdel", fakeChangedKey},
+	"xtrim":            {"xtrim", fakeChangedKey},
+	"xsetid":           {"xsetid", fakeWrittenKey},
+}
+
+// fakeFailed tells whether a command did not modify its key, from the reply.
+func fakeFailed(reply interface{}) bool {
+	switch v := reply.(type) {
+	case nil, error, respNilArr:
+		return true
+	case []string:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	case respPairs:
+		return len(v) == 0
+	}
+	return false
+}
+
+// fakeWrittenKey is the key of a command which modifies it unless the reply is an error.
+func fakeWrittenKey(args []string, reply interface{}) []string {
+	if _, ok := reply.(error); ok {
+		return nil
+	}
+	return args[:1]
+}
+
+// fakeFirstKey is the key of a command which replies nil or an empty array when nothing changed.
+func fakeFirstKey(args []string, reply interface{}) []string {
+	if fakeFailed(reply) {
+		return nil
+	}
+	return args[:1]
+}
+
+// fakeChangedKey is the key of a command which replies the count of the changes.
+func fakeChangedKey(args []string, reply interface{}) []string {
+	if n, ok := reply.(int64); fakeFailed(reply) || (ok && n <= 0) {
+		return nil
+	}
+	return args[:1]
+}
+
+// fakeSetEventKeys is the key of SET, with GET the reply is the old value or nil.
+func fakeSetEventKeys(args []string, reply interface{}) []string {
+	for _, arg := range args[2:] {
+		if strings.EqualFold(arg, "get") {
+			return fakeWrittenKey(args, reply)
+		}
+	}
+	return fakeFirstKey(args, reply)
+}
+
+// fakePairKeys are the keys of MSET and MSETNX.
+func fakePairKeys(args []string, reply interface{}) []string {
+	if n, ok := reply.(int64); fakeFailed(reply) || (ok && n <= 0) {
+		return nil
+	}
+	keys := make([]string, 0, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		keys = append(keys, args[i])
+	}
+	return keys
+}
@@ -0,0 +1,228 @@
+package redismock
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Fake", func() {
+	var client redis.Cmdable
+
+	fake := func() {
+		It("strings", func() {
+			Expect(client.Get(ctx, "key").Err()).To(Equal(redis.Nil))
+			Expect(client.Set(ctx, "key", "value", 0).Val()).To(Equal("OK"))
+			Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+			Expect(client.SetNX(ctx, "key", "other", 0).Val()).To(BeFalse())
+			Expect(client.Append(ctx, "key", "!").Val()).To(Equal(int64(6)))
+			Expect(client.GetRange(ctx, "key", 0, 2).Val()).To(Equal("val"))
+
+			Expect(client.Incr(ctx, "counter").Val()).To(Equal(int64(1)))
+			Expect(client.IncrBy(ctx, "counter", 9).Val()).To(Equal(int64(10)))
+			Expect(client.IncrByFloat(ctx, "counter", 0.5).Val()).To(Equal(10.5))
+			Expect(client.Incr(ctx, "key").Err()).To(MatchError("ERR value is not an integer or out of range"))
+
+			Expect(client.MGet(ctx, "key", "none").Val()).To(Equal([]interface{}{"value!", nil}))
+			Expect(client.Del(ctx, "key", "none").Val()).To(Equal(int64(1)))
+			Expect(client.Exists(ctx, "key").Val()).To(Equal(int64(0)))
+		})
+
+		It("hash", func() {
+			Expect(client.HSet(ctx, "hash", "f1", "v1", "f2", "v2").Val()).To(Equal(int64(2)))
+			Expect(client.HGet(ctx, "hash", "f1").Val()).To(Equal("v1"))
+			Expect(client.HGet(ctx, "hash", "f3").Err()).To(Equal(redis.Nil))
+			Expect(client.HGetAll(ctx, "hash").Val()).To(Equal(map[string]string{"f1": "v1", "f2": "v2"}))
+			Expect(client.HIncrBy(ctx, "hash", "n", 3).Val()).To(Equal(int64(3)))
+			Expect(client.HKeys(ctx, "hash").Val()).To(Equal([]string{"f1", "f2", "n"}))
+			Expect(client.HDel(ctx, "hash", "f1", "f2", "n").Val()).To(Equal(int64(3)))
+			Expect(client.Exists(ctx, "hash").Val()).To(Equal(int64(0)))
+		})
+
+		It("list", func() {
+			Expect(client.RPush(ctx, "list", "a", "b", "c").Val()).To(Equal(int64(3)))
+			Expect(client.LPush(ctx, "list", "z").Val()).To(Equal(int64(4)))
+			Expect(client.LRange(ctx, "list", 0, -1).Val()).To(Equal([]string{"z", "a", "b", "c"}))
+			Expect(client.LIndex(ctx, "list", -1).Val()).To(Equal("c"))
+			Expect(client.LPop(ctx, "list").Val()).To(Equal("z"))
+			Expect(client.RPopCount(ctx, "list", 2).Val()).To(Equal([]string{"c", "b"}))
+			Expect(client.LMove(ctx, "list", "other", "LEFT", "RIGHT").Val()).To(Equal("a"))
+			Expect(client.LLen(ctx, "list").Val()).To(Equal(int64(0)))
+			Expect(client.LPop(ctx, "list").Err()).To(Equal(redis.Nil))
+		})
+
+		It("set", func() {
+			Expect(client.SAdd(ctx, "s1", "a", "b", "c").Val()).To(Equal(int64(3)))
+			Expect(client.SAdd(ctx, "s2", "b", "c", "d").Val()).To(Equal(int64(3)))
+			Expect(client.SIsMember(ctx, "s1", "a").Val()).To(BeTrue())
+			Expect(client.SInter(ctx, "s1", "s2").Val()).To(Equal([]string{"b", "c"}))
+			Expect(client.SDiff(ctx, "s1", "s2").Val()).To(Equal([]string{"a"}))
+			Expect(client.SUnion(ctx, "s1", "s2").Val()).To(Equal([]string{"a", "b", "c", "d"}))
+			Expect(client.SRem(ctx, "s1", "a").Val()).To(Equal(int64(1)))
+			Expect(client.SCard(ctx, "s1").Val()).To(Equal(int64(2)))
+		})
+
+		It("sorted set", func() {
+			Expect(client.ZAdd(ctx, "z", redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 1, Member: "a"}).Val()).To(Equal(int64(2)))
+			Expect(client.ZIncrBy(ctx, "z", 5, "a").Val()).To(Equal(float64(6)))
+			Expect(client.ZRange(ctx, "z", 0, -1).Val()).To(Equal([]string{"b", "a"}))
+			Expect(client.ZRevRangeWithScores(ctx, "z", 0, 0).Val()).To(Equal([]redis.Z{{Score: 6, Member: "a"}}))
+			Expect(client.ZRangeByScore(ctx, "z", &redis.ZRangeBy{Min: "(2", Max: "+inf"}).Val()).To(Equal([]string{"a"}))
+			Expect(client.ZScore(ctx, "z", "b").Val()).To(Equal(float64(2)))
+			Expect(client.ZRank(ctx, "z", "a").Val()).To(Equal(int64(1)))
+			Expect(client.ZRank(ctx, "z", "c").Err()).To(Equal(redis.Nil))
+			Expect(client.ZPopMin(ctx, "z").Val()).To(Equal([]redis.Z{{Score: 2, Member: "b"}}))
+			Expect(client.ZCard(ctx, "z").Val()).To(Equal(int64(1)))
+		})
+
+		It("expire", func() {
+			Expect(client.Set(ctx, "key", "value", time.Minute).Err()).NotTo(HaveOccurred())
+			Expect(client.TTL(ctx, "key").Val()).To(Equal(time.Minute))
+			Expect(client.Persist(ctx, "key").Val()).To(BeTrue())
+			Expect(client.TTL(ctx, "key").Val()).To(Equal(time.Duration(-1)))
+
+			Expect(client.Set(ctx, "key", "value", 10*time.Millisecond).Err()).NotTo(HaveOccurred())
+			Eventually(func() error {
+				return client.Get(ctx, "key").Err()
+			}).Should(Equal(redis.Nil))
+			Expect(client.TTL(ctx, "key").Val()).To(Equal(time.Duration(-2)))
+		})
+
+		It("keys", func() {
+			for _, key := range []string{"user:1", "user:2", "order:1"} {
+				Expect(client.Set(ctx, key, "1", 0).Err()).NotTo(HaveOccurred())
+			}
+			Expect(client.Keys(ctx, "user:*").Val()).To(Equal([]string{"user:1", "user:2"}))
+			Expect(client.Type(ctx, "user:1").Val()).To(Equal("string"))
+
+			var keys []string
+			iter := client.Scan(ctx, 0, "*:1", 1).Iterator()
+			for iter.Next(ctx) {
+				keys = append(keys, iter.Val())
+			}
+			Expect(iter.Err()).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"order:1", "user:1"}))
+
+			Expect(client.Rename(ctx, "user:2", "user:3").Val()).To(Equal("OK"))
+			Expect(client.RenameNX(ctx, "user:1", "user:3").Val()).To(BeFalse())
+			Expect(client.DBSize(ctx).Val()).To(Equal(int64(3)))
+		})
+
+		It("wrong type", func() {
+			Expect(client.LPush(ctx, "list", "a").Err()).NotTo(HaveOccurred())
+			err := client.Get(ctx, "list").Err()
+			Expect(err).To(HaveOccurred())
+			Expect(redis.HasErrorPrefix(err, "WRONGTYPE")).To(BeTrue())
+			Expect(client.HSet(ctx, "list", "f", "v").Err()).To(HaveOccurred())
+		})
+
+		It("pipeline", func() {
+			var incr *redis.IntCmd
+			_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, "key", "1", 0)
+				incr = pipe.Incr(ctx, "key")
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(incr.Val()).To(Equal(int64(2)))
+
+			cmds, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Get(ctx, "key")
+				pipe.Do(ctx, "unknown")
+				return nil
+			})
+			Expect(err).To(MatchError("ERR unknown command 'unknown'"))
+			Expect(cmds[0].(*redis.StringCmd).Val()).To(Equal("2"))
+		})
+	}
+
+	Describe("client", func() {
+		BeforeEach(func() {
+			client = NewClientFake()
+		})
+
+		AfterEach(func() {
+			Expect(client.(*redis.Client).Close()).NotTo(HaveOccurred())
+		})
+
+		fake()
+	})
+
+	Describe("cluster", func() {
+		BeforeEach(func() {
+			client = NewClusterFake()
+		})
+
+		AfterEach(func() {
+			Expect(client.(*redis.ClusterClient).Close()).NotTo(HaveOccurred())
+		})
+
+		fake()
+	})
+
+	Describe("fake mock", func() {
+		var (
+			client     *redis.Client
+			clientMock ClientMock
+		)
+
+		BeforeEach(func() {
+			client, clientMock = NewClientFakeMock()
+		})
+
+		AfterEach(func() {
+			Expect(client.Close()).NotTo(HaveOccurred())
+			Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+			hasUnexpectedCall, _ := clientMock.UnexpectedCallsWereMade()
+			Expect(hasUnexpectedCall).To(BeFalse())
+		})
+
+		It("strict order", func() {
+			clientMock.ExpectSet("key2", "2", 0).SetErr(errors.New("set error"))
+
+			Expect(client.Set(ctx, "key1", "1", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Set(ctx, "key2", "2", 0).Err()).To(MatchError("set error"))
+			Expect(client.Set(ctx, "key3", "3", 0).Err()).NotTo(HaveOccurred())
+
+			Expect(client.MGet(ctx, "key1", "key2", "key3").Val()).To(Equal([]interface{}{"1", nil, "3"}))
+		})
+
+		It("not strict order", func() {
+			clientMock.MatchExpectationsInOrder(false)
+			clientMock.ExpectGet("key2").SetVal("expected")
+			clientMock.ExpectIncr("counter").RedisNil()
+
+			Expect(client.Set(ctx, "key1", "1", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Incr(ctx, "counter").Err()).To(Equal(redis.Nil))
+			Expect(client.Get(ctx, "key2").Val()).To(Equal("expected"))
+			Expect(client.Get(ctx, "key1").Val()).To(Equal("1"))
+			Expect(client.Incr(ctx, "counter").Val()).To(Equal(int64(1)))
+		})
+
+		It("regexp", func() {
+			clientMock.Regexp().ExpectSet(`user:\d+`, "x", 0).SetErr(errors.New("set error"))
+
+			Expect(client.Set(ctx, "user:1", "x", 0).Err()).To(MatchError("set error"))
+			Expect(client.Set(ctx, "user:1", "x", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Exists(ctx, "user:1").Val()).To(Equal(int64(1)))
+		})
+
+		It("state", func() {
+			clientMock.ExpectSet("key", "value", 0).SetVal("OK")
+			clientMock.ExpectIncr("counter").SetVal(10)
+			clientMock.ExpectDel("gone").SetErr(errors.New("del error"))
+
+			Expect(client.Set(ctx, "gone", "1", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Set(ctx, "key", "value", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Incr(ctx, "counter").Val()).To(Equal(int64(10)))
+			Expect(client.Del(ctx, "gone").Err()).To(MatchError("del error"))
+
+			Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+			Expect(client.Get(ctx, "counter").Val()).To(Equal("1"))
+			Expect(client.Exists(ctx, "gone").Val()).To(Equal(int64(1)))
+		})
+	})
+})
@@ -0,0 +1,411 @@
+package redismock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gopkg.in/yaml.v3"
+)
+
+// fixtureFile is the format of LoadExpectations and DumpExpectations, for example:
+//
+//	ordered: true
+//	expectations:
+//	  - command: set
+//	    args: [key, {regexp: "^v"}, {any: true}]
+//	    reply: {status: OK}
+//	    times: 2
+//	  - command: hgetall
+//	    args: [key]
+//	    reply: {map: {field: {bulk: value}}}
+type fixtureFile struct {
+	Ordered      *bool                `yaml:"ordered,omitempty"`
+	Expectations []fixtureExpectation `yaml:"expectations"`
+}
+
+type fixtureExpectation struct {
+	Command string       `yaml:"command"`
+	Args    []fixtureArg `yaml:"args,flow,omitempty"`
+	Reply   fixtureReply `yaml:"reply"`
+	Times   int          `yaml:"times,omitempty"`
+}
+
+// fixtureArg matches an argument: a scalar is matched as it is sent to Redis,
+// {regexp: expr} with a regular expression and {any: true} matches any value.
+type fixtureArg struct {
+	Value  string
+	Regexp string
+	Any    bool
+
+	re *regexp.Regexp
+}
+
+func (a *fixtureArg) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		a.Value = node.Value
+		return nil
+	}
+
+	var m struct {
+		Regexp *string `yaml:"regexp"`
+		Any    bool    `yaml:"any"`
+	}
+	if err := node.Decode(&m); err != nil {
+		return err
+	}
+	switch {
+	case m.Regexp != nil:
+		re, err := regexp.Compile(*m.Regexp)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		a.Regexp, a.re = *m.Regexp, re
+	case m.Any:
+		a.Any = true
+	default:
+		return fmt.Errorf("line %d: an argument is a scalar, {regexp: expr} or {any: true}", node.Line)
+	}
+	return nil
+}
+
+func (a fixtureArg) MarshalYAML() (interface{}, error) {
+	switch {
+	case a.Any:
+		return map[string]bool{"any": true}, nil
+	case a.re != nil:
+		return map[string]string{"regexp": a.Regexp}, nil
+	}
+	return a.Value, nil
+}
+
+func (a fixtureArg) match(arg interface{}) bool {
+	switch {
+	case a.Any:
+		return true
+	case a.re != nil:
+		return a.re.MatchString(formatArg(arg))
+	}
+	return a.Value == formatArg(arg)
+}
+
+// fixtureReply is a typed reply, exactly one of the fields is set.
+type fixtureReply struct {
+	Status *string                 `yaml:"status,omitempty"`
+	Int    *int64                  `yaml:"int,omitempty"`
+	Bulk   *string                 `yaml:"bulk,omitempty"`
+	Array  *[]fixtureReply         `yaml:"array,omitempty"`
+	Map    map[string]fixtureReply `yaml:"map,omitempty"`
+	Nil    bool                    `yaml:"nil,omitempty"`
+	Error  string                  `yaml:"error,omitempty"`
+}
+
+func (r fixtureReply) validate() error {
+	n := 0
+	for _, set := range []bool{
+		r.Status != nil, r.Int != nil, r.Bulk != nil, r.Array != nil, r.Map != nil, r.Nil, r.Error != "",
+	} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return errors.New("a reply is one of status, int, bulk, array, map, nil or error")
+	}
+	if r.Array != nil {
+		for _, v := range *r.Array {
+			if err := v.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	for _, v := range r.Map {
+		if err := v.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resp returns the reply written by a server, the keys of a map are sorted.
+func (r fixtureReply) resp() interface{} {
+	switch {
+	case r.Status != nil:
+		return respStatus(*r.Status)
+	case r.Int != nil:
+		return *r.Int
+	case r.Bulk != nil:
+		return *r.Bulk
+	case r.Array != nil:
+		vals := make([]interface{}, len(*r.Array))
+		for i, v := range *r.Array {
+			vals[i] = v.resp()
+		}
+		return vals
+	case r.Map != nil:
+		keys := make([]string, 0, len(r.Map))
+		for key := range r.Map {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		vals := respMap{}
+		for _, key := range keys {
+			vals = append(vals, key, r.Map[key].resp())
+		}
+		return vals
+	case r.Error != "":
+		return respError(r.Error)
+	}
+	return nil
+}
+
+func (r fixtureReply) raw() []byte {
+	var buf bytes.Buffer
+	w := newRESPWriter(&buf, 3)
+	w.write(r.resp())
+	_ = w.flush()
+	return buf.Bytes()
+}
+
+//------------------------------------------------------------------------------
+
+// LoadExpectations reads expectations from YAML or JSON, see fixtureFile for the format.
+func (m *mock) LoadExpectations(r io.Reader) error {
+	if m.parent != nil {
+		return m.parent.LoadExpectations(r)
+	}
+
+	var file fixtureFile
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return fmt.Errorf("redismock: expectations: %w", err)
+	}
+
+	var expected []expectation
+	for i, f := range file.Expectations {
+		if f.Command == "" {
+			return fmt.Errorf("redismock: expectation #%d: command is required", i+1)
+		}
+		if err := f.Reply.validate(); err != nil {
+			return fmt.Errorf("redismock: expectation #%d (%s): %w", i+1, f.Command, err)
+		}
+		times := f.Times
+		if times == 0 {
+			times = 1
+		}
+		for j := 0; j < times; j++ {
+			expected = append(expected, newExpectedFixture(m.ctx, f))
+		}
+	}
+
+	if file.Ordered != nil {
+		m.MatchExpectationsInOrder(*file.Ordered)
+	}
+	for _, e := range expected {
+		m.pushExpect(e)
+	}
+	return nil
+}
+
+// expectedFixture is an expectation loaded by LoadExpectations,
+// the reply is decoded by go-redis into the Cmd when called.
+type expectedFixture struct {
+	expectedBase
+
+	fixture fixtureExpectation
+}
+
+func newExpectedFixture(ctx context.Context, f fixtureExpectation) *expectedFixture {
+	e := &expectedFixture{fixture: f}
+	e.fixture.Times = 0
+
+	args := make([]interface{}, 1+len(f.Args))
+	args[0] = f.Command
+	for i, arg := range f.Args {
+		args[i+1] = arg.Value
+	}
+	e.cmd = redis.NewCmd(ctx, args...)
+	e.setCustomMatch(func(_, actual []interface{}) error {
+		for i, arg := range f.Args {
+			if !arg.match(actual[i+1]) {
+				return fmt.Errorf("args not match, expectation: '%+v', but gave: '%+v'", f.Args, formatArgs(actual))
+			}
+		}
+		return nil
+	})
+
+	switch {
+	case f.Reply.Nil:
+		e.RedisNil()
+	case f.Reply.Error != "":
+		_, err := ParseRESP(f.Reply.raw())
+		e.SetErr(err)
+	default:
+		e.setVal = true
+	}
+	return e
+}
+
+func (cmd *expectedFixture) inflow(c redis.Cmder) error {
+	if err := processRESP(c, cmd.fixture.Reply.raw()); err != nil {
+		return err
+	}
+	return c.Err()
+}
+
+//------------------------------------------------------------------------------
+
+// DumpExpectations writes the registered expectations in the YAML format of LoadExpectations.
+// The values of the expectations are converted to replies, custom matches and
+// values of struct types are not supported.
+func (m *mock) DumpExpectations(w io.Writer) error {
+	if m.parent != nil {
+		return m.parent.DumpExpectations(w)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ordered := m.strictOrder
+	file := fixtureFile{Ordered: &ordered, Expectations: []fixtureExpectation{}}
+	for _, e := range m.expected {
+		e.lock()
+		f, err := dumpExpectation(e)
+		e.unlock()
+		if err != nil {
+			return fmt.Errorf("redismock: dump %s: %w", e.name(), err)
+		}
+		file.Expectations = append(file.Expectations, f)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(file); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func dumpExpectation(e expectation) (fixtureExpectation, error) {
+	if f, ok := e.(*expectedFixture); ok {
+		return f.fixture, nil
+	}
+	if e.custom() != nil {
+		return fixtureExpectation{}, errors.New("custom match is not supported")
+	}
+
+	args := e.args()
+	f := fixtureExpectation{Command: e.name()}
+	for _, arg := range args[1:] {
+		a := fixtureArg{Value: formatArg(arg)}
+		if e.regexp() {
+			if _, ok := arg.(string); ok {
+				a.Regexp, a.re = a.Value, regexp.MustCompile(a.Value)
+			}
+		}
+		f.Args = append(f.Args, a)
+	}
+
+	switch {
+	case e.error() != nil:
+		f.Reply.Error = e.error().Error()
+		return f, nil
+	case e.isRedisNil():
+		f.Reply.Nil = true
+		return f, nil
+	case !e.isSetVal():
+		return f, errors.New("return value is required")
+	}
+
+	// the value is set into a Cmd of the command type to read it with Val
+	cmd := reflect.New(reflect.TypeOf(e.command()).Elem()).Interface().(redis.Cmder)
+	if err := e.inflow(cmd); err != nil {
+		return f, err
+	}
+	val := goldenVal(cmd)
+	if _, ok := cmd.(*redis.StatusCmd); ok {
+		s := val.(string)
+		f.Reply.Status = &s
+		return f, nil
+	}
+	reply, err := dumpReply(reflect.ValueOf(val))
+	if err != nil {
+		return f, err
+	}
+	f.Reply = reply
+	return f, nil
+}
+
+// dumpReply converts a value into the reply go-redis decodes into it.
+func dumpReply(v reflect.Value) (fixtureReply, error) {
+	var r fixtureReply
+	if !v.IsValid() {
+		r.Nil = true
+		return r, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			r.Nil = true
+			return r, nil
+		}
+		return dumpReply(v.Elem())
+	case reflect.String:
+		s := v.String()
+		r.Bulk = &s
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			return r, errors.New("duration values are not supported")
+		}
+		n := v.Int()
+		r.Int = &n
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := int64(v.Uint())
+		r.Int = &n
+	case reflect.Bool:
+		var n int64
+		if v.Bool() {
+			n = 1
+		}
+		r.Int = &n
+	case reflect.Float32, reflect.Float64:
+		s := formatFloat(v.Float())
+		r.Bulk = &s
+	case reflect.Slice, reflect.Array:
+		arr := make([]fixtureReply, v.Len())
+		for i := range arr {
+			item, err := dumpReply(v.Index(i))
+			if err != nil {
+				return r, err
+			}
+			arr[i] = item
+		}
+		r.Array = &arr
+	case reflect.Map:
+		if v.Len() == 0 {
+			// an empty map is omitted in YAML, go-redis reads an empty array as well
+			r.Array = &[]fixtureReply{}
+			return r, nil
+		}
+		r.Map = make(map[string]fixtureReply, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			item, err := dumpReply(iter.Value())
+			if err != nil {
+				return r, err
+			}
+			r.Map[fmt.Sprint(iter.Key().Interface())] = item
+		}
+	default:
+		return r, fmt.Errorf("value of type %s is not supported", v.Type())
+	}
+	return r, nil
+}
@@ -0,0 +1,136 @@
+package redismock
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Fixture", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientMock()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("yaml", func() {
+		err := clientMock.LoadExpectations(strings.NewReader(`
+expectations:
+  - command: set
+    args: [key, {regexp: "^v[0-9]$"}, {any: true}, "60"]
+    reply: {status: OK}
+    times: 2
+  - command: incr
+    args: [counter]
+    reply: {int: 3}
+  - command: get
+    args: [none]
+    reply: {nil: true}
+  - command: hgetall
+    args: [hash]
+    reply: {map: {f1: {bulk: v1}, f2: {bulk: v2}}}
+  - command: lrange
+    args: [list, 0, -1]
+    reply: {array: [{bulk: a}, {bulk: b}]}
+  - command: lpush
+    args: [key, a]
+    reply: {error: "WRONGTYPE Operation against a key holding the wrong kind of value"}
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Set(ctx, "key", "v1", time.Minute).Val()).To(Equal("OK"))
+		Expect(client.Set(ctx, "key", "v2", time.Minute).Val()).To(Equal("OK"))
+		Expect(client.Incr(ctx, "counter").Val()).To(Equal(int64(3)))
+		Expect(client.Get(ctx, "none").Err()).To(Equal(redis.Nil))
+		Expect(client.HGetAll(ctx, "hash").Val()).To(Equal(map[string]string{"f1": "v1", "f2": "v2"}))
+		Expect(client.LRange(ctx, "list", 0, -1).Val()).To(Equal([]string{"a", "b"}))
+
+		err = client.LPush(ctx, "key", "a").Err()
+		Expect(redis.HasErrorPrefix(err, "WRONGTYPE")).To(BeTrue())
+	})
+
+	It("json", func() {
+		err := clientMock.LoadExpectations(strings.NewReader(`{
+			"ordered": false,
+			"expectations": [
+				{"command": "get", "args": ["a"], "reply": {"bulk": "1"}},
+				{"command": "get", "args": ["b"], "reply": {"bulk": "2"}}
+			]
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Get(ctx, "b").Val()).To(Equal("2"))
+		Expect(client.Get(ctx, "a").Val()).To(Equal("1"))
+	})
+
+	It("invalid", func() {
+		for _, fixture := range []string{
+			`expectations: [{command: get, args: [key], reply: {bulk: a, int: 1}}]`,
+			`expectations: [{command: get, args: [key], reply: {}}]`,
+			`expectations: [{args: [key], reply: {nil: true}}]`,
+			`expectations: [{command: get, args: [{regexp: "("}], reply: {nil: true}}]`,
+			`expectations: [{command: get, args: [{other: 1}], reply: {nil: true}}]`,
+			`expectations: [{command: get, unknown: 1, reply: {nil: true}}]`,
+		} {
+			Expect(clientMock.LoadExpectations(strings.NewReader(fixture))).To(HaveOccurred(), fixture)
+		}
+	})
+
+	It("dump", func() {
+		clientMock.ExpectSet("key", "value", 0).SetVal("OK")
+		clientMock.ExpectHGetAll("hash").SetVal(map[string]string{"f": "v"})
+		clientMock.ExpectLRange("list", 0, -1).SetVal([]string{"a", "b"})
+		clientMock.ExpectExists("key").SetVal(1)
+		clientMock.ExpectSIsMember("set", "a").SetVal(true)
+		clientMock.ExpectGet("none").RedisNil()
+		clientMock.ExpectGet("err").SetErr(errors.New("ERR failed"))
+		clientMock.Regexp().ExpectGet(`^user:\d+$`).SetVal("user")
+
+		var buf bytes.Buffer
+		Expect(clientMock.DumpExpectations(&buf)).NotTo(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring(`args: [key, value]`))
+		Expect(buf.String()).To(ContainSubstring(`{regexp: '^user:\d+$'}`))
+
+		_, loadMock := NewClientMock()
+		Expect(loadMock.LoadExpectations(&buf)).NotTo(HaveOccurred())
+
+		var again bytes.Buffer
+		Expect(loadMock.DumpExpectations(&again)).NotTo(HaveOccurred())
+		buf.Reset()
+		Expect(clientMock.DumpExpectations(&buf)).NotTo(HaveOccurred())
+		Expect(again.String()).To(Equal(buf.String()))
+
+		clientMock.ClearExpect()
+		load, loadMock := NewClientMock()
+		Expect(loadMock.LoadExpectations(&again)).NotTo(HaveOccurred())
+		Expect(load.Set(ctx, "key", "value", 0).Val()).To(Equal("OK"))
+		Expect(load.HGetAll(ctx, "hash").Val()).To(Equal(map[string]string{"f": "v"}))
+		Expect(load.LRange(ctx, "list", 0, -1).Val()).To(Equal([]string{"a", "b"}))
+		Expect(load.Exists(ctx, "key").Val()).To(Equal(int64(1)))
+		Expect(load.SIsMember(ctx, "set", "a").Val()).To(BeTrue())
+		Expect(load.Get(ctx, "none").Err()).To(Equal(redis.Nil))
+		Expect(load.Get(ctx, "err").Err()).To(MatchError("ERR failed"))
+		Expect(load.Get(ctx, "user:1").Val()).To(Equal("user"))
+		Expect(loadMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+		Expect(load.Close()).NotTo(HaveOccurred())
+	})
+
+	It("dump unsupported", func() {
+		clientMock.ExpectZRangeWithScores("z", 0, -1).SetVal([]redis.Z{{Score: 1, Member: "a"}})
+		Expect(clientMock.DumpExpectations(&bytes.Buffer{})).To(HaveOccurred())
+		clientMock.ClearExpect()
+	})
+})
@@ -0,0 +1,426 @@
+package redismock
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gopkg.in/yaml.v3"
+)
+
+// goldenUpdateEnv is the environment variable recording the golden files instead of replaying them.
+const goldenUpdateEnv = "REDISMOCK_UPDATE"
+
+// goldenVersion is the version of the golden file format.
+const goldenVersion = 1
+
+type goldenFile struct {
+	Version  int             `json:"version" yaml:"version"`
+	Commands []goldenCommand `json:"commands" yaml:"commands"`
+}
+
+// goldenCommand is a command with its reply, only one of Val, Nil and Err is set.
+type goldenCommand struct {
+	Args []string    `json:"args" yaml:"args,flow"`
+	Val  interface{} `json:"val,omitempty" yaml:"val,omitempty"`
+	Nil  bool        `json:"nil,omitempty" yaml:"nil,omitempty"`
+	Err  string      `json:"err,omitempty" yaml:"err,omitempty"`
+}
+
+// NewClientGolden returns a client replaying the golden file at path. With REDISMOCK_UPDATE=1 in the environment,
+// the commands are sent to a Redis server connected with opt and recorded into the file instead.
+// finish writes the file when recording, or checks all the commands were replayed.
+func NewClientGolden(path string, opt *redis.Options) (client *redis.Client, finish func() error, err error) {
+	if update, _ := strconv.ParseBool(os.Getenv(goldenUpdateEnv)); update {
+		client = redis.NewClient(opt)
+		rec := NewRecorder(client)
+		return client, func() error {
+			return rec.WriteFile(path)
+		}, nil
+	}
+
+	client, mock := NewClientMock()
+	if err = mock.ExpectGoldenFile(path); err != nil {
+		return nil, nil, err
+	}
+	return client, mock.ExpectationsWereMet, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Recorder logs the commands sent by a client, with their replies, into a golden file.
+type Recorder struct {
+	mu       sync.Mutex
+	commands []goldenCommand
+}
+
+// NewRecorder adds a hook to client recording the commands, pipelines and transactions included.
+func NewRecorder(client *redis.Client) *Recorder {
+	rec := &Recorder{}
+	client.AddHook(rec)
+	return rec
+}
+
+func (rec *Recorder) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (rec *Recorder) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		// the error is set on cmd after the hooks
+		err := next(ctx, cmd)
+		cmd.SetErr(err)
+		rec.record(cmd)
+		return err
+	}
+}
+
+func (rec *Recorder) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		rec.record(cmds...)
+		return err
+	}
+}
+
+func (rec *Recorder) record(cmds ...redis.Cmder) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	for _, cmd := range cmds {
+		c := goldenCommand{Args: formatArgs(cmd.Args())}
+		switch err := cmd.Err(); {
+		case err == redis.Nil:
+			c.Nil = true
+		case err != nil:
+			c.Err = err.Error()
+		default:
+			c.Val = goldenVal(cmd)
+		}
+		rec.commands = append(rec.commands, c)
+	}
+}
+
+// Write writes the recorded commands in the golden format, "json" or "yaml".
+func (rec *Recorder) Write(w io.Writer, format string) error {
+	rec.mu.Lock()
+	file := goldenFile{Version: goldenVersion, Commands: rec.commands}
+	rec.mu.Unlock()
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(file)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(file); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	return fmt.Errorf("redismock: unknown golden format %q", format)
+}
+
+// WriteFile writes the recorded commands to path, the format is chosen by the extension.
+func (rec *Recorder) WriteFile(path string) error {
+	format, err := goldenFormat(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err = rec.Write(&buf, format); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+func goldenFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json", nil
+	case ".yaml", ".yml":
+		return "yaml", nil
+	}
+	return "", fmt.Errorf("redismock: unknown golden file extension %q, use .json, .yaml or .yml", filepath.Ext(path))
+}
+
+// goldenVal returns the value of the Cmd, a slice if Val returns several values.
+func goldenVal(cmd redis.Cmder) interface{} {
+	fn := reflect.ValueOf(cmd).MethodByName("Val")
+	if !fn.IsValid() {
+		return nil
+	}
+	out := fn.Call(nil)
+	if len(out) == 1 {
+		return goldenJSON(out[0].Interface())
+	}
+	vals := make([]interface{}, len(out))
+	for i, v := range out {
+		vals[i] = goldenJSON(v.Interface())
+	}
+	return vals
+}
+
+// goldenJSON converts the maps with interface keys of RESP3 replies, JSON only accepts string keys.
+func goldenJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprint(key)] = goldenJSON(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			s[i] = goldenJSON(val)
+		}
+		return s
+	}
+	return v
+}
+
+// formatArgs formats the arguments as go-redis writes them to the connection.
+func formatArgs(args []interface{}) []string {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		s[i] = formatArg(arg)
+	}
+	return s
+}
+
+func formatArg(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 64)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return strconv.FormatInt(v.Nanoseconds(), 10)
+	case encoding.BinaryMarshaler:
+		b, err := v.MarshalBinary()
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	case net.IP:
+		return string(v)
+	}
+	return fmt.Sprint(v)
+}
+
+//------------------------------------------------------------------------------
+
+// ExpectGoldenFile loads a golden file written by a Recorder as an ordered list of expectations,
+// the format is chosen by the extension.
+func (m *mock) ExpectGoldenFile(path string) error {
+	format, err := goldenFormat(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var file goldenFile
+	if format == "json" {
+		err = json.NewDecoder(f).Decode(&file)
+	} else {
+		err = yaml.NewDecoder(f).Decode(&file)
+	}
+	if err != nil {
+		return fmt.Errorf("redismock: golden file %s: %w", path, err)
+	}
+	if file.Version != goldenVersion {
+		return fmt.Errorf("redismock: golden file %s: unsupported version %d", path, file.Version)
+	}
+
+	for _, c := range file.Commands {
+		if len(c.Args) == 0 {
+			return fmt.Errorf("redismock: golden file %s: command without args", path)
+		}
+		e, err := newExpectedGolden(m.ctx, c)
+		if err != nil {
+			return fmt.Errorf("redismock: golden file %s: %w", path, err)
+		}
+		m.pushExpect(e)
+	}
+	return nil
+}
+
+// expectedGolden replays a recorded command, the value is decoded into the type of the Cmd when called.
+type expectedGolden struct {
+	expectedBase
+
+	val interface{}
+}
+
+func newExpectedGolden(ctx context.Context, c goldenCommand) (*expectedGolden, error) {
+	e := &expectedGolden{val: c.Val}
+
+	args := make([]interface{}, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = arg
+	}
+	e.cmd = redis.NewCmd(ctx, args...)
+	e.setCustomMatch(matchGoldenArgs)
+
+	switch {
+	case c.Nil:
+		e.RedisNil()
+	case c.Err != "":
+		// decoded by go-redis to be a Redis error
+		_, err := ParseRESP([]byte("-" + c.Err + "\r\n"))
+		if err == nil {
+			return nil, fmt.Errorf("invalid error %q", c.Err)
+		}
+		e.SetErr(err)
+	default:
+		e.setVal = true
+	}
+	return e, nil
+}
+
+// matchGoldenArgs compares the arguments as they are sent to Redis.
+func matchGoldenArgs(expected, actual []interface{}) error {
+	for i := range expected {
+		if formatArg(expected[i]) != formatArg(actual[i]) {
+			return fmt.Errorf("args not match, golden: '%+v', but gave: '%+v'",
+				formatArgs(expected), formatArgs(actual))
+		}
+	}
+	return nil
+}
+
+func (cmd *expectedGolden) inflow(c redis.Cmder) error {
+	fn := reflect.ValueOf(c).MethodByName("SetVal")
+	if !fn.IsValid() {
+		return fmt.Errorf("cmd(%s), %T has no SetVal", c.Name(), c)
+	}
+
+	vals := []interface{}{cmd.val}
+	if n := fn.Type().NumIn(); n > 1 {
+		s, ok := cmd.val.([]interface{})
+		if !ok || len(s) != n {
+			return fmt.Errorf("cmd(%s), golden value must be a list of %d values", c.Name(), n)
+		}
+		vals = s
+	}
+
+	in := make([]reflect.Value, len(vals))
+	for i, val := range vals {
+		v, err := decodeGolden(val, fn.Type().In(i))
+		if err != nil {
+			return fmt.Errorf("cmd(%s), golden value: %w", c.Name(), err)
+		}
+		in[i] = v
+	}
+	fn.Call(in)
+	return nil
+}
+
+// decodeGolden converts a value loaded from JSON or YAML into typ.
+func decodeGolden(val interface{}, typ reflect.Type) (reflect.Value, error) {
+	b, err := json.Marshal(goldenJSON(val))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	v := reflect.New(typ)
+	if err = dec.Decode(v.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	switch val := v.Elem().Interface().(type) {
+	case []interface{}:
+		goldenInterface(val)
+	case map[string]interface{}:
+		for key := range val {
+			val[key] = goldenInterface(val[key])
+		}
+	default:
+		if typ.Kind() == reflect.Interface && val != nil {
+			v.Elem().Set(reflect.ValueOf(goldenInterface(val)))
+		}
+	}
+	return v.Elem(), nil
+}
+
+// goldenInterface restores the types go-redis uses in an interface{}: int64 for the integers
+// and map[interface{}]interface{} for the maps of RESP3.
+func goldenInterface(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = goldenInterface(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for key, val := range v {
+			m[key] = goldenInterface(val)
+		}
+		return m
+	}
+	return v
+}
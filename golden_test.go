@@ -0,0 +1,123 @@
+package redismock
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Golden", func() {
+	var (
+		dir string
+		opt *redis.Options
+	)
+
+	// workflow sends the commands recorded then replayed by the tests
+	workflow := func(client *redis.Client) {
+		Expect(client.Set(ctx, "key", "1", time.Minute).Val()).To(Equal("OK"))
+		Expect(client.Incr(ctx, "key").Val()).To(Equal(int64(2)))
+		Expect(client.Get(ctx, "none").Err()).To(Equal(redis.Nil))
+		Expect(client.HSet(ctx, "hash", "f1", "v1", "f2", 2).Val()).To(Equal(int64(2)))
+		Expect(client.HGetAll(ctx, "hash").Val()).To(Equal(map[string]string{"f1": "v1", "f2": "2"}))
+		Expect(client.ZAdd(ctx, "z", redis.Z{Score: 1.5, Member: "a"}).Val()).To(Equal(int64(1)))
+		Expect(client.ZRangeWithScores(ctx, "z", 0, -1).Val()).To(Equal([]redis.Z{{Score: 1.5, Member: "a"}}))
+		Expect(client.Do(ctx, "lrange", "none", 0, -1).Val()).To(Equal([]interface{}{}))
+		Expect(client.Do(ctx, "incrby", "key", 3).Val()).To(Equal(int64(5)))
+
+		keys, cursor, err := client.Scan(ctx, 0, "*", 10).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{"hash", "key", "z"}))
+		Expect(cursor).To(Equal(uint64(0)))
+
+		err = client.LPush(ctx, "key", "a").Err()
+		Expect(redis.HasErrorPrefix(err, "WRONGTYPE")).To(BeTrue())
+
+		var incr *redis.IntCmd
+		_, err = client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, "tx", "1", 0)
+			incr = pipe.Incr(ctx, "tx")
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(incr.Val()).To(Equal(int64(2)))
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "redismock")
+		Expect(err).NotTo(HaveOccurred())
+
+		// the fake stands for the Redis server
+		store := newFakeStore(time.Now)
+		opt = &redis.Options{
+			Dialer: func(_ context.Context, _, _ string) (net.Conn, error) {
+				client, server := net.Pipe()
+				go serveRESP(server, store.handle)
+				return client, nil
+			},
+			Protocol:         2,
+			DisableIndentity: true,
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).NotTo(HaveOccurred())
+	})
+
+	for _, ext := range []string{".json", ".yaml"} {
+		ext := ext
+
+		It("record and replay "+ext, func() {
+			path := filepath.Join(dir, "testdata", "workflow"+ext)
+
+			server := redis.NewClient(opt)
+			rec := NewRecorder(server)
+			workflow(server)
+			Expect(rec.WriteFile(path)).NotTo(HaveOccurred())
+			Expect(server.Close()).NotTo(HaveOccurred())
+
+			client, clientMock := NewClientMock()
+			Expect(clientMock.ExpectGoldenFile(path)).NotTo(HaveOccurred())
+			workflow(client)
+			Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+			Expect(client.Close()).NotTo(HaveOccurred())
+		})
+	}
+
+	It("update", func() {
+		path := filepath.Join(dir, "workflow.yaml")
+
+		Expect(os.Setenv(goldenUpdateEnv, "1")).To(Succeed())
+		client, finish, err := NewClientGolden(path, opt)
+		Expect(os.Unsetenv(goldenUpdateEnv)).To(Succeed())
+		Expect(err).NotTo(HaveOccurred())
+		workflow(client)
+		Expect(finish()).NotTo(HaveOccurred())
+
+		client, finish, err = NewClientGolden(path, opt)
+		Expect(err).NotTo(HaveOccurred())
+		workflow(client)
+		Expect(finish()).NotTo(HaveOccurred())
+	})
+
+	It("mismatch", func() {
+		path := filepath.Join(dir, "get.json")
+		Expect(os.WriteFile(path, []byte(`{"version": 1, "commands": [{"args": ["get", "key"], "val": "1"}]}`), 0o644)).
+			NotTo(HaveOccurred())
+
+		client, clientMock := NewClientMock()
+		Expect(clientMock.ExpectGoldenFile(path)).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "other").Err()).To(HaveOccurred())
+		Expect(client.Get(ctx, "key").Val()).To(Equal("1"))
+
+		Expect(os.WriteFile(path, []byte(`{"version": 2, "commands": []}`), 0o644)).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectGoldenFile(path)).To(MatchError(ContainSubstring("unsupported version 2")))
+		Expect(clientMock.ExpectGoldenFile(filepath.Join(dir, "get.txt"))).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,217 @@
+package redismock
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// processMode tells how a command was sent to the mock.
+type processMode int
+
+const (
+	processSingle processMode = iota
+	processPipeline
+	processTx
+)
+
+// HistoryEntry is a command received by the mock, expected or not.
+type HistoryEntry struct {
+	// Seq numbers the commands in the order they arrived, from 1.
+	Seq int64
+	// Time is the time of the Clock of the mock when the command arrived.
+	Time time.Time
+	// Client is the address of the client of a Server, empty for the clients of the hook.
+	Client string
+	// Node is the name of the node of a Cluster which received the command.
+	Node string
+
+	Args []interface{}
+	// Expected is the Cmd of the matched expectation, nil for the unexpected commands
+	// the commands served by the fake and the commands failed by Chaos.
+	Expected redis.Cmder
+	// Result is the value of the command, Err its error.
+	Result interface{}
+	Err    error
+
+	Duration time.Duration
+	Pipeline bool
+	Tx       bool
+}
+
+// String returns the command in the format of MONITOR, as printed by redis-cli monitor.
+func (e HistoryEntry) String() string {
+	client := e.Client
+	if client == "" {
+		client = "127.0.0.1:0"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d.%06d [0 %s]", e.Time.Unix(), e.Time.Nanosecond()/1000, client)
+	for _, arg := range formatArgs(e.Args) {
+		b.WriteByte(' ')
+		b.WriteString(monitorQuote(arg))
+	}
+	return b.String()
+}
+
+// monitorQuote quotes an argument as Redis does in MONITOR.
+func monitorQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\', '"':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\a':
+			b.WriteString(`\a`)
+		case '\b':
+			b.WriteString(`\b`)
+		default:
+			if c < 0x20 || c > 0x7e {
+				fmt.Fprintf(&b, `\x%02x`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// history is the log of the commands of a mock and the monitors receiving them.
+type history struct {
+	mu       sync.Mutex
+	seq      int64
+	entries  []HistoryEntry
+	monitors map[int]func(line string)
+	next     int
+}
+
+// start numbers a command as it arrives and writes it to the monitors, as Redis does before running it.
+func (h *history) start(e *HistoryEntry) {
+	h.mu.Lock()
+	h.seq++
+	e.Seq = h.seq
+	monitors := make([]func(string), 0, len(h.monitors))
+	for _, fn := range h.monitors {
+		monitors = append(monitors, fn)
+	}
+	h.mu.Unlock()
+
+	line := e.String()
+	for _, fn := range monitors {
+		fn(line)
+	}
+}
+
+func (h *history) add(e HistoryEntry) {
+	h.mu.Lock()
+	h.entries = append(h.entries, e)
+	h.mu.Unlock()
+}
+
+func (h *history) monitor(fn func(line string)) (stop func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.monitors == nil {
+		h.monitors = make(map[int]func(string))
+	}
+	id := h.next
+	h.next++
+	h.monitors[id] = fn
+
+	return func() {
+		h.mu.Lock()
+		delete(h.monitors, id)
+		h.mu.Unlock()
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// process executes a command and records it in the history, a command failed by fault is recorded only.
+func (m *mock) process(ctx context.Context, cmd redis.Cmder, mode processMode, fault error) error {
+	entry := HistoryEntry{
+		Time:     m.clock.Now(),
+		Args:     append([]interface{}(nil), cmd.Args()...),
+		Pipeline: mode == processPipeline,
+		Tx:       mode == processTx,
+	}
+	w, wire := cmd.(*wireCmd)
+	if wire {
+		entry.Client = w.conn.RemoteAddr().String()
+		if w.node != nil {
+			entry.Node = w.node.name
+		}
+	}
+	m.history.start(&entry)
+
+	if fault != nil {
+		cmd.SetErr(fault)
+		entry.Err = fault
+		m.history.add(entry)
+		return fault
+	}
+
+	begin := time.Now()
+	matched, err := m.run(ctx, cmd)
+	entry.Duration = time.Since(begin)
+
+	if matched != nil {
+		entry.Expected = matched.command()
+	}
+	if wire {
+		entry.Result = w.value()
+	} else {
+		entry.Result = goldenVal(cmd)
+	}
+	entry.Err = cmd.Err()
+	m.history.add(entry)
+	return err
+}
+
+// History returns the commands processed by the mock in the order they arrived,
+// with their expectation, result and whether they were sent in a pipeline or a transaction.
+func (m *mock) History() []HistoryEntry {
+	if m.parent != nil {
+		return m.parent.History()
+	}
+
+	m.history.mu.Lock()
+	entries := append([]HistoryEntry(nil), m.history.entries...)
+	m.history.mu.Unlock()
+
+	// concurrent commands are recorded when they complete
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Seq < entries[j].Seq })
+	return entries
+}
+
+// Monitor writes the commands received by the mock to w as they arrive, a line each in the format of
+// redis-cli monitor, until stop is called. The clients of a Server may also send MONITOR.
+func (m *mock) Monitor(w io.Writer) (stop func()) {
+	if m.parent != nil {
+		return m.parent.Monitor(w)
+	}
+
+	var mu sync.Mutex
+	return m.history.monitor(func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = io.WriteString(w, line+"\n")
+	})
+}
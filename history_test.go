@@ -0,0 +1,180 @@
+package redismock
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("History", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientMock()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("commands", func() {
+		get := clientMock.ExpectGet("key")
+		get.SetVal("value")
+		clientMock.ExpectSet("key", "1", 0).SetErr(errors.New("FAIL"))
+		clientMock.ExpectIncr("a").SetVal(1)
+		clientMock.ExpectIncr("b").SetVal(2)
+		clientMock.ExpectTxPipeline()
+		clientMock.ExpectDel("a").SetVal(1)
+		clientMock.ExpectTxPipelineExec()
+
+		Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+		Expect(client.Set(ctx, "key", "1", 0).Err()).To(HaveOccurred())
+		_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Incr(ctx, "a")
+			pipe.Incr(ctx, "b")
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, "a")
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "none").Err()).To(HaveOccurred())
+
+		history := clientMock.History()
+		Expect(history).To(HaveLen(8))
+		for i, e := range history {
+			Expect(e.Seq).To(Equal(int64(i + 1)))
+		}
+
+		Expect(history[0].Args).To(Equal([]interface{}{"get", "key"}))
+		Expect(history[0].Expected).To(BeIdenticalTo(get.cmd))
+		Expect(history[0].Result).To(Equal("value"))
+		Expect(history[0].Err).NotTo(HaveOccurred())
+		Expect(history[0].Pipeline || history[0].Tx).To(BeFalse())
+
+		Expect(history[1].Err).To(MatchError("FAIL"))
+
+		Expect(history[2].Pipeline).To(BeTrue())
+		Expect(history[3].Result).To(Equal(int64(2)))
+
+		for _, e := range history[4:7] {
+			Expect(e.Tx).To(BeTrue())
+		}
+		Expect(history[4].Args).To(Equal([]interface{}{"multi"}))
+		Expect(history[6].Args).To(Equal([]interface{}{"exec"}))
+
+		Expect(history[7].Expected).To(BeNil())
+		Expect(history[7].Err).To(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("monitor", func() {
+		clientMock.Clock().Set(time.Unix(1339518083, 107412000))
+
+		var buf bytes.Buffer
+		stop := clientMock.Monitor(&buf)
+		clientMock.ExpectSet("key", "a \"b\"\n\x01", 0).SetVal("OK")
+		Expect(client.Set(ctx, "key", "a \"b\"\n\x01", 0).Err()).NotTo(HaveOccurred())
+
+		stop()
+		clientMock.ExpectGet("key").SetVal("value")
+		client.Get(ctx, "key")
+
+		Expect(buf.String()).To(Equal(`1339518083.107412 [0 127.0.0.1:0] "set" "key" "a \"b\"\n\x01"` + "\n"))
+		Expect(clientMock.History()).To(HaveLen(2))
+	})
+
+	It("chaos", func() {
+		clientMock.Chaos(1, &ChaosPolicy{Rate: 1, Faults: []ChaosFault{ChaosError}, Errors: []error{BusyError()}})
+		var buf bytes.Buffer
+		stop := clientMock.Monitor(&buf)
+		defer stop()
+
+		Expect(client.Get(ctx, "key").Err()).To(MatchError(BusyError()))
+		_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Incr(ctx, "a")
+			return nil
+		})
+		Expect(err).To(MatchError(BusyError()))
+
+		history := clientMock.History()
+		Expect(history).To(HaveLen(2))
+		Expect(history[0].Args).To(Equal([]interface{}{"get", "key"}))
+		Expect(history[0].Expected).To(BeNil())
+		Expect(history[0].Err).To(MatchError(BusyError()))
+		Expect(history[1].Pipeline).To(BeTrue())
+		Expect(history[1].Err).To(MatchError(BusyError()))
+		Expect(buf.String()).To(ContainSubstring(`"get" "key"`))
+		Expect(buf.String()).To(ContainSubstring(`"incr" "a"`))
+	})
+
+	It("monitor on arrival", func() {
+		blpop := clientMock.ExpectBLPop(time.Minute, "queue")
+		blpop.SetVal([]string{"queue", "job"})
+		blocking := blpop.Block()
+
+		var buf bytes.Buffer
+		stop := clientMock.Monitor(&buf)
+		defer stop()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- client.BLPop(ctx, time.Minute, "queue").Err()
+		}()
+
+		// the command is written before it runs, and recorded once it completes
+		<-blocking.Parked()
+		Expect(buf.String()).To(ContainSubstring(`"blpop" "queue" "60"`))
+		Expect(clientMock.History()).To(BeEmpty())
+
+		blocking.Release()
+		Expect(<-done).NotTo(HaveOccurred())
+		Expect(clientMock.History()).To(HaveLen(1))
+	})
+
+	It("server", func() {
+		srv, err := NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+		defer srv.Close()
+
+		monitor := dialRaw(srv)
+		defer monitor.Close()
+		Expect(monitor.do("monitor")).To(Equal("OK"))
+
+		conn := dialRaw(srv)
+		defer conn.Close()
+
+		clientMock.ExpectHGetAll("hash").SetVal(map[string]string{"f": "v"})
+		clientMock.ExpectTxPipeline()
+		clientMock.ExpectIncr("key").SetVal(1)
+		clientMock.ExpectTxPipelineExec()
+
+		Expect(conn.do("hgetall", "hash")).To(Equal([]interface{}{"f", "v"}))
+		Expect(monitor.receive()).To(MatchRegexp(`^\d+\.\d{6} \[0 127\.0\.0\.1:\d+\] "hgetall" "hash"$`))
+
+		Expect(conn.do("multi")).To(Equal("OK"))
+		Expect(conn.do("incr", "key")).To(Equal("QUEUED"))
+		Expect(conn.do("exec")).To(Equal([]interface{}{int64(1)}))
+		Expect(monitor.receive()).To(HaveSuffix(`"multi"`))
+		Expect(monitor.receive()).To(HaveSuffix(`"incr" "key"`))
+		Expect(monitor.receive()).To(HaveSuffix(`"exec"`))
+
+		history := clientMock.History()
+		Expect(history).To(HaveLen(4))
+		Expect(history[0].Client).To(Equal(conn.LocalAddr().String()))
+		Expect(history[0].Result).To(Equal([]interface{}{"f", "v"}))
+		Expect(history[0].Tx).To(BeFalse())
+		Expect(history[2].Tx).To(BeTrue())
+		Expect(history[2].Result).To(Equal(int64(1)))
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,380 @@
+// Command genmock generates the Expect* wrappers of the mock and the
+// interface that lists them, from the Cmdable interfaces of go-redis.
+//
+// It is run by `go generate` from the root of the module:
+//
+//	//go:generate go run ./internal/genmock -o mock_gen.go
+//
+// Every method returning *redis.XxxCmd is mapped to ExpectedXxx, which must
+// be declared in the redismock package. A Cmd type without a counterpart
+// makes the generator fail, so that the mock never silently lags the client.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const redisModule = "github.com/redis/go-redis/v9"
+
+// roots are the go-redis interfaces implemented by the mock factory,
+// keep in sync with mockCmdable.
+var roots = []string{"Cmdable", "BitMapCmdable", "StreamCmdable"}
+
+// skip are the methods that do not send a single command.
+var skip = map[string]bool{
+	"Pipeline":     true,
+	"Pipelined":    true,
+	"TxPipeline":   true,
+	"TxPipelined":  true,
+	"Subscribe":    true,
+	"PSubscribe":   true,
+	"SSubscribe":   true,
+	"Watch":        true,
+	"ForEachShard": true,
+}
+
+func main() {
+	var (
+		output = flag.String("o", "mock_gen.go", "output file")
+		dir    = flag.String("redis", "", "go-redis source directory, resolved with `go list` by default")
+		pkg    = flag.String("pkg", ".", "directory of the redismock package")
+	)
+	flag.Parse()
+
+	if err := run(*output, *dir, *pkg); err != nil {
+		fmt.Fprintf(os.Stderr, "genmock: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(output, dir, pkg string) error {
+	if dir == "" {
+		out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", redisModule).Output()
+		if err != nil {
+			return fmt.Errorf("locate %s: %w", redisModule, err)
+		}
+		dir = strings.TrimSpace(string(out))
+	}
+
+	ifaces, err := parseInterfaces(dir)
+	if err != nil {
+		return err
+	}
+	methods, err := collect(ifaces, roots)
+	if err != nil {
+		return err
+	}
+
+	expected, err := parseExpected(pkg, output)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, m := range methods {
+		if !expected[m.expected] {
+			missing = append(missing, fmt.Sprintf("%s (%s returns *redis.%s)", m.expected, m.name, m.cmd))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no Expected type for:\n\t%s", strings.Join(missing, "\n\t"))
+	}
+
+	src, err := render(methods)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(pkg, output), src, 0o644)
+}
+
+type method struct {
+	name     string
+	cmd      string
+	expected string
+	params   []param
+}
+
+type param struct {
+	names    []string
+	typ      string
+	variadic bool
+}
+
+func parseInterfaces(dir string) (map[string]*ast.InterfaceType, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := pkgs["redis"]
+	if !ok {
+		return nil, fmt.Errorf("package redis not found in %s", dir)
+	}
+
+	ifaces := make(map[string]*ast.InterfaceType)
+	for _, f := range p.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if it, ok := ts.Type.(*ast.InterfaceType); ok {
+					ifaces[ts.Name.Name] = it
+				}
+			}
+		}
+	}
+	return ifaces, nil
+}
+
+// collect walks the interfaces in source order, embedded interfaces are expanded in place.
+func collect(ifaces map[string]*ast.InterfaceType, names []string) ([]method, error) {
+	var (
+		methods []method
+		seen    = make(map[string]bool)
+		walk    func(name string) error
+	)
+
+	walk = func(name string) error {
+		it, ok := ifaces[name]
+		if !ok {
+			return fmt.Errorf("interface redis.%s not found", name)
+		}
+		for _, field := range it.Methods.List {
+			if len(field.Names) == 0 {
+				ident, ok := field.Type.(*ast.Ident)
+				if !ok {
+					return fmt.Errorf("redis.%s: unsupported embedded type %T", name, field.Type)
+				}
+				if err := walk(ident.Name); err != nil {
+					return err
+				}
+				continue
+			}
+
+			fn := field.Names[0].Name
+			if skip[fn] || seen[fn] {
+				continue
+			}
+			seen[fn] = true
+
+			m, err := newMethod(fn, field.Type.(*ast.FuncType))
+			if err != nil {
+				return fmt.Errorf("redis.%s.%s: %w", name, fn, err)
+			}
+			methods = append(methods, m)
+		}
+		return nil
+	}
+
+	for _, name := range names {
+		if err := walk(name); err != nil {
+			return nil, err
+		}
+	}
+	return methods, nil
+}
+
+func newMethod(name string, fn *ast.FuncType) (method, error) {
+	m := method{name: name}
+
+	if fn.Results == nil || len(fn.Results.List) != 1 {
+		return m, fmt.Errorf("expected a single *Cmd result")
+	}
+	star, ok := fn.Results.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return m, fmt.Errorf("expected a single *Cmd result")
+	}
+	ident, ok := star.X.(*ast.Ident)
+	if !ok || !strings.HasSuffix(ident.Name, "Cmd") {
+		return m, fmt.Errorf("expected a single *Cmd result")
+	}
+	m.cmd = ident.Name
+	m.expected = "Expected" + strings.TrimSuffix(ident.Name, "Cmd")
+	if ident.Name == "Cmd" {
+		m.expected = "ExpectedCmd"
+	}
+
+	params := fn.Params.List
+	if len(params) == 0 || typeString(params[0].Type) != "context.Context" {
+		return m, fmt.Errorf("expected context.Context as first parameter")
+	}
+	if len(params[0].Names) > 1 {
+		return m, fmt.Errorf("unexpected parameters grouped with context.Context")
+	}
+	for _, field := range params[1:] {
+		p := param{typ: typeString(field.Type)}
+		if ell, ok := field.Type.(*ast.Ellipsis); ok {
+			p.variadic = true
+			p.typ = "..." + typeString(ell.Elt)
+		}
+		if len(field.Names) == 0 {
+			return m, fmt.Errorf("unnamed parameter")
+		}
+		for _, n := range field.Names {
+			p.names = append(p.names, paramName(n.Name))
+		}
+		m.params = append(m.params, p)
+	}
+	return m, nil
+}
+
+// typeString prints a type of package redis as seen from package redismock.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "redis." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "[" + typeString(t.Len) + "]" + typeString(t.Elt)
+		}
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.BasicLit:
+		return t.Value
+	default:
+		panic(fmt.Sprintf("unsupported type %T", expr))
+	}
+}
+
+// parseExpected returns the Expected* types declared in the redismock package.
+func parseExpected(dir, output string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := pkgs["redismock"]
+	if !ok {
+		return nil, fmt.Errorf("package redismock not found in %s", dir)
+	}
+
+	types := make(map[string]bool)
+	for _, f := range p.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				name := spec.(*ast.TypeSpec).Name.Name
+				if strings.HasPrefix(name, "Expected") {
+					types[name] = true
+				}
+			}
+		}
+	}
+	return types, nil
+}
+
+func render(methods []method) ([]byte, error) {
+	var (
+		buf     bytes.Buffer
+		imports = map[string]bool{`"github.com/redis/go-redis/v9"`: true}
+	)
+
+	for _, m := range methods {
+		for _, p := range m.params {
+			if strings.Contains(p.typ, "time.") {
+				imports[`"time"`] = true
+			}
+		}
+	}
+	var list []string
+	for imp := range imports {
+		list = append(list, imp)
+	}
+	sort.Strings(list)
+
+	fmt.Fprintf(&buf, "// Code generated by genmock. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package redismock\n\n")
+	fmt.Fprintf(&buf, "import (\n%s\n)\n\n", strings.Join(list, "\n"))
+
+	fmt.Fprintf(&buf, "type expectCmdable interface {\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\tExpect%s(%s) *%s\n", m.name, m.signature(), m.expected)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\nfunc (m *mock) Expect%s(%s) *%s {\n", m.name, m.signature(), m.expected)
+		fmt.Fprintf(&buf, "\te := &%s{}\n", m.expected)
+		if call := m.call(); call != "" {
+			fmt.Fprintf(&buf, "\te.cmd = m.factory.%s(m.ctx, %s)\n", m.name, call)
+		} else {
+			fmt.Fprintf(&buf, "\te.cmd = m.factory.%s(m.ctx)\n", m.name)
+		}
+		fmt.Fprintf(&buf, "\tm.pushExpect(e)\n")
+		fmt.Fprintf(&buf, "\treturn e\n")
+		fmt.Fprintf(&buf, "}\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// paramName lower-cases the first rune of a parameter name of go-redis, the names
+// clashing with a keyword or with the receiver and the variables of the wrappers get a suffix.
+func paramName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	name = string(unicode.ToLower(r)) + name[size:]
+	if token.IsKeyword(name) || name == "m" || name == "e" {
+		name += "Arg"
+	}
+	return name
+}
+
+func (m method) signature() string {
+	var list []string
+	for _, p := range m.params {
+		list = append(list, strings.Join(p.names, ", ")+" "+p.typ)
+	}
+	return strings.Join(list, ", ")
+}
+
+func (m method) call() string {
+	var list []string
+	for _, p := range m.params {
+		for _, n := range p.names {
+			if p.variadic {
+				n += "..."
+			}
+			list = append(list, n)
+		}
+	}
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,182 @@
+package redismock
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// commandSpec is the key positions and the flags of a command, as COMMAND replies them,
+// last is negative from the end of the arguments.
+type commandSpec struct {
+	flags             []string
+	first, last, step int
+}
+
+var commandSpecs = map[string]commandSpec{}
+
+func init() {
+	for _, group := range []struct {
+		spec  commandSpec
+		names string
+	}{
+		{commandSpec{[]string{"readonly"}, 1, 1, 1}, "get getrange substr strlen getbit bitcount bitpos type ttl pttl " +
+			"expiretime pexpiretime dump hget hmget hgetall hkeys hvals hlen hexists hstrlen hrandfield hscan " +
+			"lrange llen lindex lpos smembers sismember smismember scard srandmember sscan sort_ro " +
+			"zrange zrangebyscore zrangebylex zrevrange zrevrangebyscore zrevrangebylex zscore zmscore zcard zcount " +
+			"zlexcount zrank zrevrank zrandmember zscan xrange xrevrange xlen xpending " +
+			"geopos geodist geohash georadius_ro georadiusbymember_ro geosearch"},
+		{commandSpec{[]string{"readonly"}, 1, -1, 1}, "mget exists touch sinter sunion sdiff pfcount"},
+		{commandSpec{[]string{"readonly"}, 1, 2, 1}, "lcs"},
+		{commandSpec{[]string{"write"}, 1, 1, 1}, "set setnx setex psetex getset getdel getex incr decr incrby decrby " +
+			"incrbyfloat append setrange setbit hset hmset hsetnx hdel hincrby hincrbyfloat " +
+			"lpush rpush lpushx rpushx lpop rpop lset lrem ltrim linsert sadd srem spop " +
+			"zadd zincrby zrem zremrangebyrank zremrangebyscore zremrangebylex zpopmin zpopmax " +
+			"expire pexpire expireat pexpireat persist restore move xadd xtrim xdel xack xclaim xautoclaim xsetid " +
+			"geoadd pfadd"},
+		{commandSpec{[]string{"write"}, 1, -1, 1}, "del unlink sinterstore sunionstore sdiffstore pfmerge"},
+		{commandSpec{[]string{"write"}, 1, -1, 2}, "mset msetnx"},
+		{commandSpec{[]string{"write"}, 1, 2, 1}, "rename renamenx rpoplpush lmove smove copy blmove brpoplpush " +
+			"geosearchstore zrangestore"},
+		{commandSpec{[]string{"write"}, 1, -2, 1}, "blpop brpop bzpopmin bzpopmax"},
+		{commandSpec{[]string{"write"}, 2, -1, 1}, "bitop"},
+		{commandSpec{[]string{"write"}, 1, 1, 1}, "zunionstore zinterstore zdiffstore"},
+		{commandSpec{[]string{"readonly"}, 2, 2, 1}, "object xinfo"},
+		{commandSpec{[]string{"write"}, 2, 2, 1}, "xgroup"},
+		{commandSpec{[]string{"fast"}, 1, -1, 1}, "watch"},
+
+		// the destination follows STORE or STOREDIST
+		{commandSpec{[]string{"write", "movablekeys"}, 1, 1, 1}, "sort georadius georadiusbymember"},
+
+		// the keys are counted by an argument or follow STREAMS, go-redis finds them itself
+		{commandSpec{[]string{"noscript"}, 0, 0, 0}, "eval evalsha fcall"},
+		{commandSpec{[]string{"readonly", "noscript"}, 0, 0, 0}, "eval_ro evalsha_ro fcall_ro"},
+		{commandSpec{[]string{"readonly"}, 0, 0, 0}, "xread zunion zinter zdiff sintercard zintercard"},
+		{commandSpec{[]string{"write"}, 0, 0, 0}, "xreadgroup lmpop zmpop blmpop bzmpop"},
+
+		// commands without keys
+		{commandSpec{[]string{"readonly"}, 0, 0, 0}, "dbsize keys scan randomkey"},
+		{commandSpec{[]string{"write"}, 0, 0, 0}, "flushall flushdb"},
+		{commandSpec{[]string{"fast"}, 0, 0, 0}, "ping echo multi exec discard unwatch select hello auth quit " +
+			"readonly readwrite asking time info config client cluster command script function publish " +
+			"subscribe unsubscribe psubscribe punsubscribe monitor"},
+	} {
+		for _, name := range strings.Fields(group.names) {
+			commandSpecs[name] = group.spec
+		}
+	}
+}
+
+// readonlyCommand tells whether the command only reads its keys, the replicas serve it after READONLY.
+func readonlyCommand(name string) bool {
+	spec := commandSpecs[strings.ToLower(name)]
+	return len(spec.flags) > 0 && spec.flags[0] == "readonly"
+}
+
+// commandKeys returns the keys of a command, in the order of the arguments.
+func commandKeys(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	name := strings.ToLower(args[0])
+	switch name {
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		return numKeys(args, 2)
+	case "zunionstore", "zinterstore", "zdiffstore":
+		if len(args) < 2 {
+			return nil
+		}
+		return append([]string{args[1]}, numKeys(args, 2)...)
+	case "zunion", "zinter", "zdiff", "sintercard", "zintercard", "lmpop", "zmpop":
+		return numKeys(args, 1)
+	case "blmpop", "bzmpop":
+		return numKeys(args, 2)
+	case "xread", "xreadgroup":
+		for i := 1; i < len(args); i++ {
+			if strings.EqualFold(args[i], "streams") {
+				streams := args[i+1:]
+				return streams[:len(streams)/2]
+			}
+		}
+		return nil
+	case "sort":
+		return storeKeys(args, 2, map[string]int{"by": 1, "limit": 2, "get": 1})
+	case "georadius":
+		return storeKeys(args, 6, map[string]int{"count": 1})
+	case "georadiusbymember":
+		return storeKeys(args, 5, map[string]int{"count": 1})
+	}
+
+	spec, ok := commandSpecs[name]
+	if !ok || spec.first == 0 || spec.first >= len(args) {
+		return nil
+	}
+	last := spec.last
+	if last < 0 {
+		last += len(args)
+	}
+	var keys []string
+	for i := spec.first; i <= last && i < len(args); i += spec.step {
+		keys = append(keys, args[i])
+	}
+	return keys
+}
+
+// numKeys returns the keys counted by the argument at i, as EVAL args[2] and ZUNION args[1].
+func numKeys(args []string, i int) []string {
+	if i >= len(args) {
+		return nil
+	}
+	n, err := strconv.Atoi(args[i])
+	if err != nil || n < 0 || i+1+n > len(args) {
+		return nil
+	}
+	return args[i+1 : i+1+n]
+}
+
+// storeKeys returns the key of SORT or GEORADIUS and the destination of its STORE or STOREDIST option,
+// the options start at i and the values of the options of skip are not read as keywords.
+func storeKeys(args []string, i int, skip map[string]int) []string {
+	if len(args) < 2 {
+		return nil
+	}
+	keys := []string{args[1]}
+	for ; i < len(args); i++ {
+		opt := strings.ToLower(args[i])
+		switch {
+		case (opt == "store" || opt == "storedist") && i+1 < len(args):
+			i++
+			keys = append(keys, args[i])
+		default:
+			i += skip[opt]
+		}
+	}
+	return keys
+}
+
+// commandReply is the reply of COMMAND for the commands of commandSpecs.
+func commandReply() []interface{} {
+	names := make([]string, 0, len(commandSpecs))
+	for name := range commandSpecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reply := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		spec := commandSpecs[name]
+		arity := -1
+		if cmd, ok := fakeCommands[name]; ok {
+			arity = cmd.arity
+		}
+		flags := make([]interface{}, len(spec.flags))
+		for i, flag := range spec.flags {
+			flags[i] = respStatus(flag)
+		}
+		reply = append(reply, []interface{}{
+			name, int64(arity), flags, int64(spec.first), int64(spec.last), int64(spec.step),
+		})
+	}
+	return reply
+}
@@ -0,0 +1,541 @@
+package redismock
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// RunLua enables the execution of the Lua scripts of EVAL, EVALSHA, EVAL_RO, EVALSHA_RO, FCALL and FCALL_RO
+// by the fake, with SCRIPT and FUNCTION to load them. redis.call and redis.pcall execute the commands
+// against the store of the fake, the replies are converted between Lua and RESP as Redis does.
+// It panics if the mock has no fake, see NewClientFakeMock.
+func (m *mock) RunLua(on bool) {
+	if m.parent != nil {
+		m.parent.RunLua(on)
+		return
+	}
+	if m.fake == nil {
+		panic("redismock: RunLua runs the scripts against a fake, see NewClientFakeMock")
+	}
+
+	s := m.fake.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lua = on
+}
+
+// luaTimeLimit stops the scripts running longer, as the busy-script timeout of Redis, so that
+// an endless loop fails with BUSY instead of hanging the tests and the other connections.
+var luaTimeLimit = 5 * time.Second
+
+// fakeLibrary is a library of functions loaded by FUNCTION LOAD.
+type fakeLibrary struct {
+	name string
+	code string
+	// functions are the names of the functions, true for the flag no-writes
+	functions map[string]bool
+}
+
+// luaFunction is a function registered by redis.register_function.
+type luaFunction struct {
+	fn       *lua.LFunction
+	noWrites bool
+}
+
+func fakeEval(s *fakeStore, args []string) interface{} {
+	return s.eval("eval", args[0], args[1:], false)
+}
+
+func fakeEvalRO(s *fakeStore, args []string) interface{} {
+	return s.eval("eval_ro", args[0], args[1:], true)
+}
+
+func fakeEvalSHA(s *fakeStore, args []string) interface{} {
+	return s.evalSHA("evalsha", args, false)
+}
+
+func fakeEvalSHARO(s *fakeStore, args []string) interface{} {
+	return s.evalSHA("evalsha_ro", args, true)
+}
+
+func (s *fakeStore) evalSHA(name string, args []string, readonly bool) interface{} {
+	if !s.lua {
+		return errUnknown(name)
+	}
+	src, ok := s.scripts[strings.ToLower(args[0])]
+	if !ok {
+		return respError(msgNoScript)
+	}
+	return s.eval(name, src, args[1:], readonly)
+}
+
+// eval runs a script with the keys and args of EVAL, the script is cached for EVALSHA.
+func (s *fakeStore) eval(name, src string, args []string, readonly bool) interface{} {
+	if !s.lua {
+		return errUnknown(name)
+	}
+	keys, argv, err := scriptArgs(args)
+	if err != nil {
+		return err
+	}
+	sha := s.loadScript(src)
+
+	L, done := s.newLua(readonly)
+	defer done()
+	fn, compileErr := L.Load(strings.NewReader(src), "user_script")
+	if compileErr != nil {
+		return respError("ERR Error compiling script (new function): " + luaMessage(compileErr))
+	}
+	L.SetGlobal("KEYS", luaStrings(L, keys))
+	L.SetGlobal("ARGV", luaStrings(L, argv))
+	return luaRun(L, fn, "script: "+sha)
+}
+
+func (s *fakeStore) loadScript(src string) string {
+	sha := scriptSHA(src)
+	if s.scripts == nil {
+		s.scripts = make(map[string]string)
+	}
+	s.scripts[sha] = src
+	return sha
+}
+
+func fakeScript(s *fakeStore, args []string) interface{} {
+	if !s.lua {
+		return errUnknown("script")
+	}
+	switch sub := strings.ToLower(args[0]); {
+	case sub == "load" && len(args) == 2:
+		return s.loadScript(args[1])
+	case sub == "exists" && len(args) > 1:
+		replies := make([]interface{}, len(args)-1)
+		for i, sha := range args[1:] {
+			_, ok := s.scripts[strings.ToLower(sha)]
+			replies[i] = encodeBool(ok)
+		}
+		return replies
+	case sub == "flush" && len(args) <= 2:
+		s.scripts = nil
+		return respStatus("OK")
+	case sub == "load" || sub == "exists" || sub == "flush":
+		return errArity("script|" + sub)
+	}
+	return respError("ERR unknown subcommand '" + args[0] + "'. Try SCRIPT HELP.")
+}
+
+func fakeFCall(s *fakeStore, args []string) interface{} {
+	return s.fcall("fcall", args[0], args[1:], false)
+}
+
+func fakeFCallRO(s *fakeStore, args []string) interface{} {
+	return s.fcall("fcall_ro", args[0], args[1:], true)
+}
+
+// fcall runs a function of a library with the keys and args of FCALL.
+func (s *fakeStore) fcall(name, function string, args []string, readonly bool) interface{} {
+	if !s.lua {
+		return errUnknown(name)
+	}
+	keys, argv, err := scriptArgs(args)
+	if err != nil {
+		return err
+	}
+
+	var lib *fakeLibrary
+	var noWrites bool
+	for _, l := range s.libraries {
+		if flag, ok := l.functions[function]; ok {
+			lib, noWrites = l, flag
+		}
+	}
+	if lib == nil {
+		return respError("ERR Function not found")
+	}
+	if readonly && !noWrites {
+		return respError("ERR Can not execute a script with write flag using *_ro command.")
+	}
+
+	L, done := s.newLua(readonly || noWrites)
+	defer done()
+	fns, loadErr := registerFunctions(L, lib.code)
+	if loadErr != nil {
+		return loadErr
+	}
+	return luaRun(L, fns[function].fn, "script: "+function+", on @user_function", luaStrings(L, keys), luaStrings(L, argv))
+}
+
+func fakeFunction(s *fakeStore, args []string) interface{} {
+	if !s.lua {
+		return errUnknown("function")
+	}
+	switch sub := strings.ToLower(args[0]); sub {
+	case "load":
+		replace := len(args) == 3 && strings.EqualFold(args[1], "replace")
+		if len(args) != 2 && !replace {
+			return errArity("function|load")
+		}
+		return s.loadLibrary(args[len(args)-1], replace)
+	case "delete":
+		if len(args) != 2 {
+			return errArity("function|delete")
+		}
+		if _, ok := s.libraries[args[1]]; !ok {
+			return respError("ERR Library not found")
+		}
+		delete(s.libraries, args[1])
+		return respStatus("OK")
+	case "flush":
+		if len(args) > 2 {
+			return errArity("function|flush")
+		}
+		s.libraries = nil
+		return respStatus("OK")
+	}
+	return respError("ERR unknown subcommand '" + args[0] + "'. Try FUNCTION HELP.")
+}
+
+// loadLibrary registers the functions of the code of FUNCTION LOAD, starting with #!lua name=<library>.
+func (s *fakeStore) loadLibrary(code string, replace bool) interface{} {
+	header := strings.Fields(strings.SplitN(code, "\n", 2)[0])
+	if len(header) < 2 || header[0] != "#!lua" || !strings.HasPrefix(header[1], "name=") {
+		return respError("ERR Missing library metadata")
+	}
+	lib := &fakeLibrary{name: strings.TrimPrefix(header[1], "name="), code: code, functions: map[string]bool{}}
+	if _, ok := s.libraries[lib.name]; ok && !replace {
+		return respError("ERR Library '" + lib.name + "' already exists")
+	}
+
+	L, done := s.newLua(false)
+	defer done()
+	fns, err := registerFunctions(L, code)
+	if err != nil {
+		return err
+	}
+	if len(fns) == 0 {
+		return respError("ERR No functions registered")
+	}
+	for name, fn := range fns {
+		for _, other := range s.libraries {
+			if _, ok := other.functions[name]; ok && other.name != lib.name {
+				return respError("ERR Function " + name + " already exists")
+			}
+		}
+		lib.functions[name] = fn.noWrites
+	}
+
+	if s.libraries == nil {
+		s.libraries = make(map[string]*fakeLibrary)
+	}
+	s.libraries[lib.name] = lib
+	return lib.name
+}
+
+// registerFunctions runs the code of a library and returns its functions, registered by redis.register_function.
+func registerFunctions(L *lua.LState, code string) (map[string]luaFunction, interface{}) {
+	// the header is a comment for Lua
+	code = "--" + code
+
+	fns := map[string]luaFunction{}
+	api := L.GetGlobal("redis")
+	loading := L.NewTable()
+	L.SetField(loading, "register_function", L.NewFunction(func(L *lua.LState) int {
+		var name string
+		var fn luaFunction
+		if t, ok := L.Get(1).(*lua.LTable); ok {
+			name = lua.LVAsString(t.RawGetString("function_name"))
+			fn.fn, _ = t.RawGetString("callback").(*lua.LFunction)
+			if flags, ok := t.RawGetString("flags").(*lua.LTable); ok {
+				flags.ForEach(func(_, flag lua.LValue) {
+					fn.noWrites = fn.noWrites || flag.String() == "no-writes"
+				})
+			}
+		} else {
+			name, fn.fn = L.CheckString(1), L.CheckFunction(2)
+		}
+		if name == "" || fn.fn == nil {
+			L.RaiseError("wrong arguments to redis.register_function")
+		}
+		if _, ok := fns[name]; ok {
+			L.RaiseError("Function already exists in the library")
+		}
+		fns[name] = fn
+		return 0
+	}))
+	L.SetField(loading, "log", L.NewFunction(func(*lua.LState) int { return 0 }))
+	L.SetGlobal("redis", loading)
+
+	fn, err := L.Load(strings.NewReader(code), "user_function")
+	if err != nil {
+		return nil, respError("ERR Error compiling function: " + luaMessage(err))
+	}
+	L.Push(fn)
+	if err := L.PCall(0, 0, nil); err != nil {
+		if L.Context().Err() != nil {
+			return nil, respError(msgBusy)
+		}
+		return nil, respError("ERR Error registering functions: " + luaMessage(err))
+	}
+
+	// the functions call the commands once loaded
+	L.SetGlobal("redis", api)
+	return fns, nil
+}
+
+//------------------------------------------------------------------------------
+
+// newLua returns an interpreter with the libraries available to the scripts and the redis API,
+// the write commands fail in a read-only script. The interpreter stops after luaTimeLimit,
+// done releases it.
+func (s *fakeStore) newLua(readonly bool) (L *lua.LState, done func()) {
+	L = lua.NewState(lua.Options{SkipOpenLibs: true})
+	ctx, cancel := context.WithTimeout(context.Background(), luaTimeLimit)
+	L.SetContext(ctx)
+	done = func() {
+		cancel()
+		L.Close()
+	}
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.fn))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	// the scripts cannot access the files
+	for _, name := range []string{"dofile", "loadfile"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+
+	api := L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"call": func(L *lua.LState) int {
+			return s.luaCall(L, readonly, true)
+		},
+		"pcall": func(L *lua.LState) int {
+			return s.luaCall(L, readonly, false)
+		},
+		"error_reply": func(L *lua.LState) int {
+			L.Push(luaReplyTable(L, "err", L.CheckString(1)))
+			return 1
+		},
+		"status_reply": func(L *lua.LState) int {
+			L.Push(luaReplyTable(L, "ok", L.CheckString(1)))
+			return 1
+		},
+		"sha1hex": func(L *lua.LState) int {
+			L.Push(lua.LString(scriptSHA(L.CheckString(1))))
+			return 1
+		},
+		"log": func(*lua.LState) int {
+			return 0
+		},
+	})
+	for i, level := range []string{"LOG_DEBUG", "LOG_VERBOSE", "LOG_NOTICE", "LOG_WARNING"} {
+		L.SetField(api, level, lua.LNumber(i))
+	}
+	L.SetGlobal("redis", api)
+	return L, done
+}
+
+// luaCall executes the command of redis.call, which raises the error replies, or redis.pcall,
+// which returns them.
+func (s *fakeStore) luaCall(L *lua.LState, readonly, raise bool) int {
+	if L.GetTop() == 0 {
+		L.RaiseError("Please specify at least one argument for this redis lib call")
+	}
+	args := make([]string, L.GetTop())
+	for i := range args {
+		switch v := L.Get(i + 1).(type) {
+		case lua.LString:
+			args[i] = string(v)
+		case lua.LNumber:
+			args[i] = strconv.FormatFloat(float64(v), 'g', 17, 64)
+		default:
+			L.RaiseError("Lua redis lib command arguments must be strings or integers")
+		}
+	}
+
+	reply := s.scriptCommand(args, readonly)
+	if err, ok := reply.(respError); ok && raise {
+		L.Error(luaReplyTable(L, "err", string(err)), 1)
+	}
+	L.Push(luaValue(L, reply))
+	return 1
+}
+
+// scriptCommand executes a command called by a script.
+func (s *fakeStore) scriptCommand(args []string, readonly bool) interface{} {
+	name := strings.ToLower(args[0])
+	if _, ok := fakeCommands[name]; !ok {
+		return respError("ERR Unknown Redis command called from script")
+	}
+	if _, err := lookupFakeCommand(args); err != nil {
+		return err
+	}
+	switch name {
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro", "script", "function", "watch", "unwatch":
+		return respError("ERR This Redis command is not allowed from script")
+	}
+	if spec := commandSpecs[name]; readonly && len(spec.flags) > 0 && spec.flags[0] == "write" {
+		return respError("ERR Write commands are not allowed from read-only scripts.")
+	}
+	return s.run(args)
+}
+
+// luaRun calls a script or a function and converts the returned value to a reply, the errors are
+// followed by where, as Redis 7 does.
+func luaRun(L *lua.LState, fn *lua.LFunction, where string, args ...lua.LValue) interface{} {
+	L.Push(fn)
+	for _, arg := range args {
+		L.Push(arg)
+	}
+	if err := L.PCall(len(args), 1, nil); err != nil {
+		if L.Context().Err() != nil {
+			return respError(msgBusy)
+		}
+		if apiErr, ok := err.(*lua.ApiError); ok {
+			if t, ok := apiErr.Object.(*lua.LTable); ok {
+				if msg, ok := t.RawGetString("err").(lua.LString); ok {
+					return respError(string(msg) + " " + where)
+				}
+			}
+		}
+		return respError("ERR " + luaMessage(err) + " " + where)
+	}
+	ret := L.Get(-1)
+	L.Pop(1)
+	return luaReply(ret)
+}
+
+// luaMessage returns the message of an error, without the stack trace.
+func luaMessage(err error) string {
+	msg := err.Error()
+	if apiErr, ok := err.(*lua.ApiError); ok && apiErr.Object != nil {
+		msg = apiErr.Object.String()
+	}
+	// an error reply is a single line
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// scriptArgs splits the keys and the args of EVAL and FCALL after numkeys.
+func scriptArgs(args []string) (keys, argv []string, err interface{}) {
+	n, convErr := strconv.Atoi(args[0])
+	switch {
+	case convErr != nil:
+		return nil, nil, errNotInt
+	case n < 0:
+		return nil, nil, respError("ERR Number of keys can't be negative")
+	case n > len(args)-1:
+		return nil, nil, respError("ERR Number of keys can't be greater than number of args")
+	}
+	return args[1 : 1+n], args[1+n:], nil
+}
+
+func errUnknown(name string) respError {
+	return respError("ERR unknown command '" + name + "'")
+}
+
+//------------------------------------------------------------------------------
+
+// luaValue converts a reply to Lua, as Redis does for RESP2: a null is false, a status or an error
+// is a table with the field ok or err, and an aggregate is an array.
+func luaValue(L *lua.LState, reply interface{}) lua.LValue {
+	switch v := reply.(type) {
+	case nil, respNilArr:
+		return lua.LFalse
+	case string:
+		return lua.LString(v)
+	case int64:
+		return lua.LNumber(v)
+	case int:
+		return lua.LNumber(v)
+	case float64:
+		return lua.LString(formatFloat(v))
+	case bool:
+		return lua.LNumber(encodeBool(v))
+	case respStatus:
+		return luaReplyTable(L, "ok", string(v))
+	case respError:
+		return luaReplyTable(L, "err", string(v))
+	case error:
+		return luaReplyTable(L, "err", v.Error())
+	case []string:
+		return luaStrings(L, v)
+	case []interface{}:
+		return luaArray(L, v)
+	case respMap:
+		return luaArray(L, v)
+	case respSet:
+		return luaArray(L, v)
+	case respPairs:
+		return luaArray(L, v)
+	case respPush:
+		return luaArray(L, v)
+	}
+	return lua.LString(fmt.Sprint(reply))
+}
+
+func luaArray(L *lua.LState, vals []interface{}) *lua.LTable {
+	t := L.CreateTable(len(vals), 0)
+	for _, v := range vals {
+		t.Append(luaValue(L, v))
+	}
+	return t
+}
+
+func luaStrings(L *lua.LState, vals []string) *lua.LTable {
+	t := L.CreateTable(len(vals), 0)
+	for _, v := range vals {
+		t.Append(lua.LString(v))
+	}
+	return t
+}
+
+func luaReplyTable(L *lua.LState, field, msg string) *lua.LTable {
+	t := L.NewTable()
+	t.RawSetString(field, lua.LString(msg))
+	return t
+}
+
+// luaReply converts a value returned by a script to a reply: a number is truncated to an integer,
+// true is 1, false and nil are null, and an array stops at its first nil.
+func luaReply(v lua.LValue) interface{} {
+	switch v := v.(type) {
+	case lua.LString:
+		return string(v)
+	case lua.LNumber:
+		return int64(v)
+	case lua.LBool:
+		if v {
+			return int64(1)
+		}
+		return nil
+	case *lua.LTable:
+		if msg, ok := v.RawGetString("ok").(lua.LString); ok {
+			return respStatus(msg)
+		}
+		if msg, ok := v.RawGetString("err").(lua.LString); ok {
+			return respError(msg)
+		}
+		replies := []interface{}{}
+		for i := 1; ; i++ {
+			e := v.RawGetInt(i)
+			if e == lua.LNil {
+				break
+			}
+			replies = append(replies, luaReply(e))
+		}
+		return replies
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package redismock
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Lua", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientFakeMock()
+		clientMock.RunLua(true)
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("eval", func() {
+		limiter := redis.NewScript(`
+			local current = redis.call('incr', KEYS[1])
+			if current == 1 then
+				redis.call('expire', KEYS[1], ARGV[1])
+			end
+			if current > tonumber(ARGV[2]) then
+				return 0
+			end
+			return current`)
+
+		for _, want := range []int64{1, 2, 0} {
+			Expect(limiter.Run(ctx, client, []string{"rate:user"}, 60, 2).Val()).To(Equal(want))
+		}
+		Expect(client.TTL(ctx, "rate:user").Val()).To(Equal(time.Minute))
+		Expect(client.Get(ctx, "rate:user").Val()).To(Equal("3"))
+
+		// EVALSHA after the fallback to EVAL
+		history := clientMock.History()
+		Expect(history[0].Args[0]).To(Equal("evalsha"))
+		Expect(history[1].Args[0]).To(Equal("eval"))
+		Expect(history[2].Args[0]).To(Equal("evalsha"))
+		Expect(history[2].Err).NotTo(HaveOccurred())
+	})
+
+	It("conversions", func() {
+		client.HSet(ctx, "hash", "field", "value")
+		client.Set(ctx, "string", "value", 0)
+
+		Expect(client.Eval(ctx, `return {1, 2.9, 'x', true, {'nested'}, false, 'lost'}`, nil).Val()).To(Equal(
+			[]interface{}{int64(1), int64(2), "x", int64(1), []interface{}{"nested"}, nil, "lost"}))
+		Expect(client.Eval(ctx, `return {'a', nil, 'lost'}`, nil).Val()).To(Equal([]interface{}{"a"}))
+		Expect(client.Eval(ctx, `return redis.call('get', 'missing') == false`, nil).Val()).To(Equal(int64(1)))
+		Expect(client.Eval(ctx, `return redis.call('hgetall', KEYS[1])`, []string{"hash"}).Val()).To(Equal(
+			[]interface{}{"field", "value"}))
+		Expect(client.Eval(ctx, `return redis.call('set', KEYS[1], ARGV[1])`, []string{"key"}, 1.5).Val()).To(Equal("OK"))
+		Expect(client.Get(ctx, "key").Val()).To(Equal("1.5"))
+		Expect(client.Eval(ctx, `return redis.call('set', 'key', 'v')['ok']`, nil).Val()).To(Equal("OK"))
+		Expect(client.Eval(ctx, `return redis.status_reply('DONE')`, nil).Val()).To(Equal("DONE"))
+		Expect(client.Eval(ctx, `return`, nil).Err()).To(Equal(redis.Nil))
+
+		// errors
+		err := client.Eval(ctx, `return redis.call('lpush', KEYS[1], 'a')`, []string{"string"}).Err()
+		Expect(redis.HasErrorPrefix(err, "WRONGTYPE")).To(BeTrue())
+		Expect(client.Eval(ctx, `return redis.pcall('lpush', KEYS[1], 'a')['err']`, []string{"string"}).Val()).To(
+			Equal(msgWrongType))
+		Expect(client.Eval(ctx, `return redis.error_reply('MY failure')`, nil).Err()).To(MatchError("MY failure"))
+		Expect(client.Eval(ctx, `error('boom')`, nil).Err()).To(MatchError(HavePrefix("ERR user_script:1: boom script: ")))
+		Expect(client.Eval(ctx, `return redis.call('nope')`, nil).Err()).To(
+			MatchError(HavePrefix("ERR Unknown Redis command called from script")))
+		Expect(client.Eval(ctx, `return (`, nil).Err()).To(MatchError(HavePrefix("ERR Error compiling script")))
+		Expect(client.Eval(ctx, `return 1`, []string{"a"}, "b").Val()).To(Equal(int64(1)))
+		Expect(client.Do(ctx, "eval", "return 1", 2, "a").Err()).To(
+			MatchError("ERR Number of keys can't be greater than number of args"))
+	})
+
+	It("scripts", func() {
+		script := redis.NewScript(`return redis.call('get', KEYS[1])`)
+		Expect(script.Exists(ctx, client).Val()).To(Equal([]bool{false}))
+		Expect(script.EvalSha(ctx, client, []string{"key"}).Err()).To(MatchError(msgNoScript))
+
+		Expect(script.Load(ctx, client).Val()).To(Equal(script.Hash()))
+		Expect(script.Exists(ctx, client).Val()).To(Equal([]bool{true}))
+		client.Set(ctx, "key", "value", 0)
+		Expect(script.EvalSha(ctx, client, []string{"key"}).Val()).To(Equal("value"))
+		Expect(script.EvalShaRO(ctx, client, []string{"key"}).Val()).To(Equal("value"))
+
+		Expect(client.ScriptFlush(ctx).Err()).NotTo(HaveOccurred())
+		Expect(script.Exists(ctx, client).Val()).To(Equal([]bool{false}))
+
+		// read-only scripts
+		Expect(client.EvalRO(ctx, `return redis.call('get', KEYS[1])`, []string{"key"}).Val()).To(Equal("value"))
+		Expect(client.EvalRO(ctx, `return redis.call('set', KEYS[1], 'x')`, []string{"key"}).Err()).To(
+			MatchError(HavePrefix("ERR Write commands are not allowed from read-only scripts.")))
+	})
+
+	It("functions", func() {
+		code := `#!lua name=mylib
+redis.register_function('knockknock', function(keys, args)
+	return redis.call('set', keys[1], args[1])
+end)
+redis.register_function{
+	function_name = 'peek',
+	callback = function(keys) return redis.call('get', keys[1]) end,
+	flags = {'no-writes'},
+}`
+		Expect(client.FunctionLoad(ctx, code).Val()).To(Equal("mylib"))
+		Expect(client.FunctionLoad(ctx, code).Err()).To(MatchError("ERR Library 'mylib' already exists"))
+		Expect(client.FunctionLoadReplace(ctx, code).Val()).To(Equal("mylib"))
+
+		Expect(client.FCall(ctx, "knockknock", []string{"door"}, "who").Val()).To(Equal("OK"))
+		Expect(client.FCallRO(ctx, "peek", []string{"door"}).Val()).To(Equal("who"))
+		Expect(client.FCallRO(ctx, "knockknock", []string{"door"}, "x").Err()).To(
+			MatchError(HavePrefix("ERR Can not execute a script with write flag")))
+
+		Expect(client.FunctionDelete(ctx, "mylib").Err()).NotTo(HaveOccurred())
+		Expect(client.FCall(ctx, "peek", []string{"door"}).Err()).To(MatchError("ERR Function not found"))
+		Expect(client.FunctionLoad(ctx, "return 1").Err()).To(MatchError("ERR Missing library metadata"))
+	})
+
+	It("time limit", func() {
+		defer func(limit time.Duration) { luaTimeLimit = limit }(luaTimeLimit)
+		luaTimeLimit = 50 * time.Millisecond
+
+		begin := time.Now()
+		Expect(client.Eval(ctx, "while true do end", nil).Err()).To(MatchError(BusyError()))
+		Expect(time.Since(begin)).To(BeNumerically(">=", luaTimeLimit))
+		// the scripts cannot catch the timeout
+		Expect(client.Eval(ctx, "while true do pcall(function() while true do end end) end", nil).Err()).To(
+			MatchError(BusyError()))
+		Expect(client.FunctionLoad(ctx, "#!lua name=loop\nwhile true do end").Err()).To(MatchError(BusyError()))
+
+		Expect(client.FunctionLoad(ctx, "#!lua name=spin\nredis.register_function('spin', function() while true do end end)").Err()).
+			NotTo(HaveOccurred())
+		Expect(client.FCall(ctx, "spin", nil).Err()).To(MatchError(BusyError()))
+
+		// the store is released
+		Expect(client.Eval(ctx, "return redis.call('set', 'key', 'value')", nil).Val()).To(Equal("OK"))
+	})
+
+	It("expectations", func() {
+		clientMock.ExpectEval("return 1", nil).SetVal(int64(2))
+		Expect(client.Eval(ctx, "return 1", nil).Val()).To(Equal(int64(2)))
+		Expect(client.Eval(ctx, "return 1", nil).Val()).To(Equal(int64(1)))
+	})
+
+	It("disabled", func() {
+		clientMock.RunLua(false)
+		Expect(client.Eval(ctx, "return 1", nil).Err()).To(MatchError("ERR unknown command 'eval'"))
+
+		_, mock := NewClientMock()
+		Expect(func() { mock.RunLua(true) }).To(Panic())
+	})
+})
@@ -0,0 +1,3443 @@
+// Code generated by genmock. DO NOT EDIT.
+
+package redismock
+
+import (
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+type expectCmdable interface {
+	ExpectCommand() *ExpectedCommandsInfo
+	ExpectCommandList(filter *redis.FilterBy) *ExpectedStringSlice
+	ExpectCommandGetKeys(commands ...interface{}) *ExpectedStringSlice
+	ExpectCommandGetKeysAndFlags(commands ...interface{}) *ExpectedKeyFlags
+	ExpectClientGetName() *ExpectedString
+	ExpectEcho(message interface{}) *ExpectedString
+	ExpectPing() *ExpectedStatus
+	ExpectQuit() *ExpectedStatus
+	ExpectUnlink(keys ...string) *ExpectedInt
+	ExpectBgRewriteAOF() *ExpectedStatus
+	ExpectBgSave() *ExpectedStatus
+	ExpectClientKill(ipPort string) *ExpectedStatus
+	ExpectClientKillByFilter(keys ...string) *ExpectedInt
+	ExpectClientList() *ExpectedString
+	ExpectClientInfo() *ExpectedClientInfo
+	ExpectClientPause(dur time.Duration) *ExpectedBool
+	ExpectClientUnpause() *ExpectedBool
+	ExpectClientID() *ExpectedInt
+	ExpectClientUnblock(id int64) *ExpectedInt
+	ExpectClientUnblockWithError(id int64) *ExpectedInt
+	ExpectConfigGet(parameter string) *ExpectedMapStringString
+	ExpectConfigResetStat() *ExpectedStatus
+	ExpectConfigSet(parameter, value string) *ExpectedStatus
+	ExpectConfigRewrite() *ExpectedStatus
+	ExpectDBSize() *ExpectedInt
+	ExpectFlushAll() *ExpectedStatus
+	ExpectFlushAllAsync() *ExpectedStatus
+	ExpectFlushDB() *ExpectedStatus
+	ExpectFlushDBAsync() *ExpectedStatus
+	ExpectInfo(section ...string) *ExpectedString
+	ExpectLastSave() *ExpectedInt
+	ExpectSave() *ExpectedStatus
+	ExpectShutdown() *ExpectedStatus
+	ExpectShutdownSave() *ExpectedStatus
+	ExpectShutdownNoSave() *ExpectedStatus
+	ExpectSlaveOf(host, port string) *ExpectedStatus
+	ExpectSlowLogGet(num int64) *ExpectedSlowLog
+	ExpectTime() *ExpectedTime
+	ExpectDebugObject(key string) *ExpectedString
+	ExpectMemoryUsage(key string, samples ...int) *ExpectedInt
+	ExpectModuleLoadex(conf *redis.ModuleLoadexConfig) *ExpectedString
+	ExpectACLDryRun(username string, command ...interface{}) *ExpectedString
+	ExpectACLLog(count int64) *ExpectedACLLog
+	ExpectACLLogReset() *ExpectedStatus
+	ExpectHDel(key string, fields ...string) *ExpectedInt
+	ExpectHExists(key, field string) *ExpectedBool
+	ExpectHGet(key, field string) *ExpectedString
+	ExpectHGetAll(key string) *ExpectedMapStringString
+	ExpectHIncrBy(key, field string, incr int64) *ExpectedInt
+	ExpectHIncrByFloat(key, field string, incr float64) *ExpectedFloat
+	ExpectHKeys(key string) *ExpectedStringSlice
+	ExpectHLen(key string) *ExpectedInt
+	ExpectHMGet(key string, fields ...string) *ExpectedSlice
+	ExpectHSet(key string, values ...interface{}) *ExpectedInt
+	ExpectHMSet(key string, values ...interface{}) *ExpectedBool
+	ExpectHSetNX(key, field string, value interface{}) *ExpectedBool
+	ExpectHScan(key string, cursor uint64, match string, count int64) *ExpectedScan
+	ExpectHVals(key string) *ExpectedStringSlice
+	ExpectHRandField(key string, count int) *ExpectedStringSlice
+	ExpectHRandFieldWithValues(key string, count int) *ExpectedKeyValueSlice
+	ExpectPFAdd(key string, els ...interface{}) *ExpectedInt
+	ExpectPFCount(keys ...string) *ExpectedInt
+	ExpectPFMerge(dest string, keys ...string) *ExpectedStatus
+	ExpectGeoAdd(key string, geoLocation ...*redis.GeoLocation) *ExpectedInt
+	ExpectGeoPos(key string, members ...string) *ExpectedGeoPos
+	ExpectGeoRadius(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *ExpectedGeoLocation
+	ExpectGeoRadiusStore(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *ExpectedInt
+	ExpectGeoRadiusByMember(key, member string, query *redis.GeoRadiusQuery) *ExpectedGeoLocation
+	ExpectGeoRadiusByMemberStore(key, member string, query *redis.GeoRadiusQuery) *ExpectedInt
+	ExpectGeoSearch(key string, q *redis.GeoSearchQuery) *ExpectedStringSlice
+	ExpectGeoSearchLocation(key string, q *redis.GeoSearchLocationQuery) *ExpectedGeoSearchLocation
+	ExpectGeoSearchStore(key, store string, q *redis.GeoSearchStoreQuery) *ExpectedInt
+	ExpectGeoDist(key string, member1, member2, unit string) *ExpectedFloat
+	ExpectGeoHash(key string, members ...string) *ExpectedStringSlice
+	ExpectDel(keys ...string) *ExpectedInt
+	ExpectDump(key string) *ExpectedString
+	ExpectExists(keys ...string) *ExpectedInt
+	ExpectExpire(key string, expiration time.Duration) *ExpectedBool
+	ExpectExpireAt(key string, tm time.Time) *ExpectedBool
+	ExpectExpireTime(key string) *ExpectedDuration
+	ExpectExpireNX(key string, expiration time.Duration) *ExpectedBool
+	ExpectExpireXX(key string, expiration time.Duration) *ExpectedBool
+	ExpectExpireGT(key string, expiration time.Duration) *ExpectedBool
+	ExpectExpireLT(key string, expiration time.Duration) *ExpectedBool
+	ExpectKeys(pattern string) *ExpectedStringSlice
+	ExpectMigrate(host, port, key string, db int, timeout time.Duration) *ExpectedStatus
+	ExpectMove(key string, db int) *ExpectedBool
+	ExpectObjectRefCount(key string) *ExpectedInt
+	ExpectObjectEncoding(key string) *ExpectedString
+	ExpectObjectIdleTime(key string) *ExpectedDuration
+	ExpectPersist(key string) *ExpectedBool
+	ExpectPExpire(key string, expiration time.Duration) *ExpectedBool
+	ExpectPExpireAt(key string, tm time.Time) *ExpectedBool
+	ExpectPExpireTime(key string) *ExpectedDuration
+	ExpectPTTL(key string) *ExpectedDuration
+	ExpectRandomKey() *ExpectedString
+	ExpectRename(key, newkey string) *ExpectedStatus
+	ExpectRenameNX(key, newkey string) *ExpectedBool
+	ExpectRestore(key string, ttl time.Duration, value string) *ExpectedStatus
+	ExpectRestoreReplace(key string, ttl time.Duration, value string) *ExpectedStatus
+	ExpectSort(key string, sort *redis.Sort) *ExpectedStringSlice
+	ExpectSortRO(key string, sort *redis.Sort) *ExpectedStringSlice
+	ExpectSortStore(key, store string, sort *redis.Sort) *ExpectedInt
+	ExpectSortInterfaces(key string, sort *redis.Sort) *ExpectedSlice
+	ExpectTouch(keys ...string) *ExpectedInt
+	ExpectTTL(key string) *ExpectedDuration
+	ExpectType(key string) *ExpectedStatus
+	ExpectCopy(sourceKey string, destKey string, db int, replace bool) *ExpectedInt
+	ExpectScan(cursor uint64, match string, count int64) *ExpectedScan
+	ExpectScanType(cursor uint64, match string, count int64, keyType string) *ExpectedScan
+	ExpectBLPop(timeout time.Duration, keys ...string) *ExpectedStringSlice
+	ExpectBLMPop(timeout time.Duration, direction string, count int64, keys ...string) *ExpectedKeyValues
+	ExpectBRPop(timeout time.Duration, keys ...string) *ExpectedStringSlice
+	ExpectBRPopLPush(source, destination string, timeout time.Duration) *ExpectedString
+	ExpectLIndex(key string, index int64) *ExpectedString
+	ExpectLInsert(key, op string, pivot, value interface{}) *ExpectedInt
+	ExpectLInsertBefore(key string, pivot, value interface{}) *ExpectedInt
+	ExpectLInsertAfter(key string, pivot, value interface{}) *ExpectedInt
+	ExpectLLen(key string) *ExpectedInt
+	ExpectLMPop(direction string, count int64, keys ...string) *ExpectedKeyValues
+	ExpectLPop(key string) *ExpectedString
+	ExpectLPopCount(key string, count int) *ExpectedStringSlice
+	ExpectLPos(key string, value string, args redis.LPosArgs) *ExpectedInt
+	ExpectLPosCount(key string, value string, count int64, args redis.LPosArgs) *ExpectedIntSlice
+	ExpectLPush(key string, values ...interface{}) *ExpectedInt
+	ExpectLPushX(key string, values ...interface{}) *ExpectedInt
+	ExpectLRange(key string, start, stop int64) *ExpectedStringSlice
+	ExpectLRem(key string, count int64, value interface{}) *ExpectedInt
+	ExpectLSet(key string, index int64, value interface{}) *ExpectedStatus
+	ExpectLTrim(key string, start, stop int64) *ExpectedStatus
+	ExpectRPop(key string) *ExpectedString
+	ExpectRPopCount(key string, count int) *ExpectedStringSlice
+	ExpectRPopLPush(source, destination string) *ExpectedString
+	ExpectRPush(key string, values ...interface{}) *ExpectedInt
+	ExpectRPushX(key string, values ...interface{}) *ExpectedInt
+	ExpectLMove(source, destination, srcpos, destpos string) *ExpectedString
+	ExpectBLMove(source, destination, srcpos, destpos string, timeout time.Duration) *ExpectedString
+	ExpectSAdd(key string, members ...interface{}) *ExpectedInt
+	ExpectSCard(key string) *ExpectedInt
+	ExpectSDiff(keys ...string) *ExpectedStringSlice
+	ExpectSDiffStore(destination string, keys ...string) *ExpectedInt
+	ExpectSInter(keys ...string) *ExpectedStringSlice
+	ExpectSInterCard(limit int64, keys ...string) *ExpectedInt
+	ExpectSInterStore(destination string, keys ...string) *ExpectedInt
+	ExpectSIsMember(key string, member interface{}) *ExpectedBool
+	ExpectSMIsMember(key string, members ...interface{}) *ExpectedBoolSlice
+	ExpectSMembers(key string) *ExpectedStringSlice
+	ExpectSMembersMap(key string) *ExpectedStringStructMap
+	ExpectSMove(source, destination string, member interface{}) *ExpectedBool
+	ExpectSPop(key string) *ExpectedString
+	ExpectSPopN(key string, count int64) *ExpectedStringSlice
+	ExpectSRandMember(key string) *ExpectedString
+	ExpectSRandMemberN(key string, count int64) *ExpectedStringSlice
+	ExpectSRem(key string, members ...interface{}) *ExpectedInt
+	ExpectSScan(key string, cursor uint64, match string, count int64) *ExpectedScan
+	ExpectSUnion(keys ...string) *ExpectedStringSlice
+	ExpectSUnionStore(destination string, keys ...string) *ExpectedInt
+	ExpectBZPopMax(timeout time.Duration, keys ...string) *ExpectedZWithKey
+	ExpectBZPopMin(timeout time.Duration, keys ...string) *ExpectedZWithKey
+	ExpectBZMPop(timeout time.Duration, order string, count int64, keys ...string) *ExpectedZSliceWithKey
+	ExpectZAdd(key string, members ...redis.Z) *ExpectedInt
+	ExpectZAddLT(key string, members ...redis.Z) *ExpectedInt
+	ExpectZAddGT(key string, members ...redis.Z) *ExpectedInt
+	ExpectZAddNX(key string, members ...redis.Z) *ExpectedInt
+	ExpectZAddXX(key string, members ...redis.Z) *ExpectedInt
+	ExpectZAddArgs(key string, args redis.ZAddArgs) *ExpectedInt
+	ExpectZAddArgsIncr(key string, args redis.ZAddArgs) *ExpectedFloat
+	ExpectZCard(key string) *ExpectedInt
+	ExpectZCount(key, min, max string) *ExpectedInt
+	ExpectZLexCount(key, min, max string) *ExpectedInt
+	ExpectZIncrBy(key string, increment float64, member string) *ExpectedFloat
+	ExpectZInter(store *redis.ZStore) *ExpectedStringSlice
+	ExpectZInterWithScores(store *redis.ZStore) *ExpectedZSlice
+	ExpectZInterCard(limit int64, keys ...string) *ExpectedInt
+	ExpectZInterStore(destination string, store *redis.ZStore) *ExpectedInt
+	ExpectZMPop(order string, count int64, keys ...string) *ExpectedZSliceWithKey
+	ExpectZMScore(key string, members ...string) *ExpectedFloatSlice
+	ExpectZPopMax(key string, count ...int64) *ExpectedZSlice
+	ExpectZPopMin(key string, count ...int64) *ExpectedZSlice
+	ExpectZRange(key string, start, stop int64) *ExpectedStringSlice
+	ExpectZRangeWithScores(key string, start, stop int64) *ExpectedZSlice
+	ExpectZRangeByScore(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
+	ExpectZRangeByLex(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
+	ExpectZRangeByScoreWithScores(key string, opt *redis.ZRangeBy) *ExpectedZSlice
+	ExpectZRangeArgs(z redis.ZRangeArgs) *ExpectedStringSlice
+	ExpectZRangeArgsWithScores(z redis.ZRangeArgs) *ExpectedZSlice
+	ExpectZRangeStore(dst string, z redis.ZRangeArgs) *ExpectedInt
+	ExpectZRank(key, member string) *ExpectedInt
+	ExpectZRankWithScore(key, member string) *ExpectedRankWithScore
+	ExpectZRem(key string, members ...interface{}) *ExpectedInt
+	ExpectZRemRangeByRank(key string, start, stop int64) *ExpectedInt
+	ExpectZRemRangeByScore(key, min, max string) *ExpectedInt
+	ExpectZRemRangeByLex(key, min, max string) *ExpectedInt
+	ExpectZRevRange(key string, start, stop int64) *ExpectedStringSlice
+	ExpectZRevRangeWithScores(key string, start, stop int64) *ExpectedZSlice
+	ExpectZRevRangeByScore(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
+	ExpectZRevRangeByLex(key string, opt *redis.ZRangeBy) *ExpectedStringSlice
+	ExpectZRevRangeByScoreWithScores(key string, opt *redis.ZRangeBy) *ExpectedZSlice
+	ExpectZRevRank(key, member string) *ExpectedInt
+	ExpectZRevRankWithScore(key, member string) *ExpectedRankWithScore
+	ExpectZScore(key, member string) *ExpectedFloat
+	ExpectZUnionStore(dest string, store *redis.ZStore) *ExpectedInt
+	ExpectZRandMember(key string, count int) *ExpectedStringSlice
+	ExpectZRandMemberWithScores(key string, count int) *ExpectedZSlice
+	ExpectZUnion(store redis.ZStore) *ExpectedStringSlice
+	ExpectZUnionWithScores(store redis.ZStore) *ExpectedZSlice
+	ExpectZDiff(keys ...string) *ExpectedStringSlice
+	ExpectZDiffWithScores(keys ...string) *ExpectedZSlice
+	ExpectZDiffStore(destination string, keys ...string) *ExpectedInt
+	ExpectZScan(key string, cursor uint64, match string, count int64) *ExpectedScan
+	ExpectClusterMyShardID() *ExpectedString
+	ExpectClusterSlots() *ExpectedClusterSlots
+	ExpectClusterShards() *ExpectedClusterShards
+	ExpectClusterLinks() *ExpectedClusterLinks
+	ExpectClusterNodes() *ExpectedString
+	ExpectClusterMeet(host, port string) *ExpectedStatus
+	ExpectClusterForget(nodeID string) *ExpectedStatus
+	ExpectClusterReplicate(nodeID string) *ExpectedStatus
+	ExpectClusterResetSoft() *ExpectedStatus
+	ExpectClusterResetHard() *ExpectedStatus
+	ExpectClusterInfo() *ExpectedString
+	ExpectClusterKeySlot(key string) *ExpectedInt
+	ExpectClusterGetKeysInSlot(slot int, count int) *ExpectedStringSlice
+	ExpectClusterCountFailureReports(nodeID string) *ExpectedInt
+	ExpectClusterCountKeysInSlot(slot int) *ExpectedInt
+	ExpectClusterDelSlots(slots ...int) *ExpectedStatus
+	ExpectClusterDelSlotsRange(min, max int) *ExpectedStatus
+	ExpectClusterSaveConfig() *ExpectedStatus
+	ExpectClusterSlaves(nodeID string) *ExpectedStringSlice
+	ExpectClusterFailover() *ExpectedStatus
+	ExpectClusterAddSlots(slots ...int) *ExpectedStatus
+	ExpectClusterAddSlotsRange(min, max int) *ExpectedStatus
+	ExpectReadOnly() *ExpectedStatus
+	ExpectReadWrite() *ExpectedStatus
+	ExpectEval(script string, keys []string, args ...interface{}) *ExpectedCmd
+	ExpectEvalSha(sha1 string, keys []string, args ...interface{}) *ExpectedCmd
+	ExpectEvalRO(script string, keys []string, args ...interface{}) *ExpectedCmd
+	ExpectEvalShaRO(sha1 string, keys []string, args ...interface{}) *ExpectedCmd
+	ExpectScriptExists(hashes ...string) *ExpectedBoolSlice
+	ExpectScriptFlush() *ExpectedStatus
+	ExpectScriptKill() *ExpectedStatus
+	ExpectScriptLoad(script string) *ExpectedString
+	ExpectFunctionLoad(code string) *ExpectedString
+	ExpectFunctionLoadReplace(code string) *ExpectedString
+	ExpectFunctionDelete(libName string) *ExpectedString
+	ExpectFunctionFlush() *ExpectedString
+	ExpectFunctionKill() *ExpectedString
+	ExpectFunctionFlushAsync() *ExpectedString
+	ExpectFunctionList(q redis.FunctionListQuery) *ExpectedFunctionList
+	ExpectFunctionDump() *ExpectedString
+	ExpectFunctionRestore(libDump string) *ExpectedString
+	ExpectFunctionStats() *ExpectedFunctionStats
+	ExpectFCall(function string, keys []string, args ...interface{}) *ExpectedCmd
+	ExpectFCallRo(function string, keys []string, args ...interface{}) *ExpectedCmd
+	ExpectFCallRO(function string, keys []string, args ...interface{}) *ExpectedCmd
+	ExpectAppend(key, value string) *ExpectedInt
+	ExpectDecr(key string) *ExpectedInt
+	ExpectDecrBy(key string, decrement int64) *ExpectedInt
+	ExpectGet(key string) *ExpectedString
+	ExpectGetRange(key string, start, end int64) *ExpectedString
+	ExpectGetSet(key string, value interface{}) *ExpectedString
+	ExpectGetEx(key string, expiration time.Duration) *ExpectedString
+	ExpectGetDel(key string) *ExpectedString
+	ExpectIncr(key string) *ExpectedInt
+	ExpectIncrBy(key string, value int64) *ExpectedInt
+	ExpectIncrByFloat(key string, value float64) *ExpectedFloat
+	ExpectLCS(q *redis.LCSQuery) *ExpectedLCS
+	ExpectMGet(keys ...string) *ExpectedSlice
+	ExpectMSet(values ...interface{}) *ExpectedStatus
+	ExpectMSetNX(values ...interface{}) *ExpectedBool
+	ExpectSet(key string, value interface{}, expiration time.Duration) *ExpectedStatus
+	ExpectSetArgs(key string, value interface{}, a redis.SetArgs) *ExpectedStatus
+	ExpectSetEx(key string, value interface{}, expiration time.Duration) *ExpectedStatus
+	ExpectSetNX(key string, value interface{}, expiration time.Duration) *ExpectedBool
+	ExpectSetXX(key string, value interface{}, expiration time.Duration) *ExpectedBool
+	ExpectSetRange(key string, offset int64, value string) *ExpectedInt
+	ExpectStrLen(key string) *ExpectedInt
+	ExpectPublish(channel string, message interface{}) *ExpectedInt
+	ExpectSPublish(channel string, message interface{}) *ExpectedInt
+	ExpectPubSubChannels(pattern string) *ExpectedStringSlice
+	ExpectPubSubNumSub(channels ...string) *ExpectedMapStringInt
+	ExpectPubSubNumPat() *ExpectedInt
+	ExpectPubSubShardChannels(pattern string) *ExpectedStringSlice
+	ExpectPubSubShardNumSub(channels ...string) *ExpectedMapStringInt
+	ExpectTFunctionLoad(lib string) *ExpectedStatus
+	ExpectTFunctionLoadArgs(lib string, options *redis.TFunctionLoadOptions) *ExpectedStatus
+	ExpectTFunctionDelete(libName string) *ExpectedStatus
+	ExpectTFunctionList() *ExpectedMapStringInterfaceSlice
+	ExpectTFunctionListArgs(options *redis.TFunctionListOptions) *ExpectedMapStringInterfaceSlice
+	ExpectTFCall(libName string, funcName string, numKeys int) *ExpectedCmd
+	ExpectTFCallArgs(libName string, funcName string, numKeys int, options *redis.TFCallOptions) *ExpectedCmd
+	ExpectTFCallASYNC(libName string, funcName string, numKeys int) *ExpectedCmd
+	ExpectTFCallASYNCArgs(libName string, funcName string, numKeys int, options *redis.TFCallOptions) *ExpectedCmd
+	ExpectBFAdd(key string, element interface{}) *ExpectedBool
+	ExpectBFCard(key string) *ExpectedInt
+	ExpectBFExists(key string, element interface{}) *ExpectedBool
+	ExpectBFInfo(key string) *ExpectedBFInfo
+	ExpectBFInfoArg(key, option string) *ExpectedBFInfo
+	ExpectBFInfoCapacity(key string) *ExpectedBFInfo
+	ExpectBFInfoSize(key string) *ExpectedBFInfo
+	ExpectBFInfoFilters(key string) *ExpectedBFInfo
+	ExpectBFInfoItems(key string) *ExpectedBFInfo
+	ExpectBFInfoExpansion(key string) *ExpectedBFInfo
+	ExpectBFInsert(key string, options *redis.BFInsertOptions, elements ...interface{}) *ExpectedBoolSlice
+	ExpectBFMAdd(key string, elements ...interface{}) *ExpectedBoolSlice
+	ExpectBFMExists(key string, elements ...interface{}) *ExpectedBoolSlice
+	ExpectBFReserve(key string, errorRate float64, capacity int64) *ExpectedStatus
+	ExpectBFReserveExpansion(key string, errorRate float64, capacity, expansion int64) *ExpectedStatus
+	ExpectBFReserveNonScaling(key string, errorRate float64, capacity int64) *ExpectedStatus
+	ExpectBFReserveWithArgs(key string, options *redis.BFReserveOptions) *ExpectedStatus
+	ExpectBFScanDump(key string, iterator int64) *ExpectedScanDump
+	ExpectBFLoadChunk(key string, iterator int64, data interface{}) *ExpectedStatus
+	ExpectCFAdd(key string, element interface{}) *ExpectedBool
+	ExpectCFAddNX(key string, element interface{}) *ExpectedBool
+	ExpectCFCount(key string, element interface{}) *ExpectedInt
+	ExpectCFDel(key string, element interface{}) *ExpectedBool
+	ExpectCFExists(key string, element interface{}) *ExpectedBool
+	ExpectCFInfo(key string) *ExpectedCFInfo
+	ExpectCFInsert(key string, options *redis.CFInsertOptions, elements ...interface{}) *ExpectedBoolSlice
+	ExpectCFInsertNX(key string, options *redis.CFInsertOptions, elements ...interface{}) *ExpectedIntSlice
+	ExpectCFMExists(key string, elements ...interface{}) *ExpectedBoolSlice
+	ExpectCFReserve(key string, capacity int64) *ExpectedStatus
+	ExpectCFReserveWithArgs(key string, options *redis.CFReserveOptions) *ExpectedStatus
+	ExpectCFReserveExpansion(key string, capacity int64, expansion int64) *ExpectedStatus
+	ExpectCFReserveBucketSize(key string, capacity int64, bucketsize int64) *ExpectedStatus
+	ExpectCFReserveMaxIterations(key string, capacity int64, maxiterations int64) *ExpectedStatus
+	ExpectCFScanDump(key string, iterator int64) *ExpectedScanDump
+	ExpectCFLoadChunk(key string, iterator int64, data interface{}) *ExpectedStatus
+	ExpectCMSIncrBy(key string, elements ...interface{}) *ExpectedIntSlice
+	ExpectCMSInfo(key string) *ExpectedCMSInfo
+	ExpectCMSInitByDim(key string, width, height int64) *ExpectedStatus
+	ExpectCMSInitByProb(key string, errorRate, probability float64) *ExpectedStatus
+	ExpectCMSMerge(destKey string, sourceKeys ...string) *ExpectedStatus
+	ExpectCMSMergeWithWeight(destKey string, sourceKeys map[string]int64) *ExpectedStatus
+	ExpectCMSQuery(key string, elements ...interface{}) *ExpectedIntSlice
+	ExpectTopKAdd(key string, elements ...interface{}) *ExpectedStringSlice
+	ExpectTopKCount(key string, elements ...interface{}) *ExpectedIntSlice
+	ExpectTopKIncrBy(key string, elements ...interface{}) *ExpectedStringSlice
+	ExpectTopKInfo(key string) *ExpectedTopKInfo
+	ExpectTopKList(key string) *ExpectedStringSlice
+	ExpectTopKListWithCount(key string) *ExpectedMapStringInt
+	ExpectTopKQuery(key string, elements ...interface{}) *ExpectedBoolSlice
+	ExpectTopKReserve(key string, k int64) *ExpectedStatus
+	ExpectTopKReserveWithOptions(key string, k int64, width, depth int64, decay float64) *ExpectedStatus
+	ExpectTDigestAdd(key string, elements ...float64) *ExpectedStatus
+	ExpectTDigestByRank(key string, rank ...uint64) *ExpectedFloatSlice
+	ExpectTDigestByRevRank(key string, rank ...uint64) *ExpectedFloatSlice
+	ExpectTDigestCDF(key string, elements ...float64) *ExpectedFloatSlice
+	ExpectTDigestCreate(key string) *ExpectedStatus
+	ExpectTDigestCreateWithCompression(key string, compression int64) *ExpectedStatus
+	ExpectTDigestInfo(key string) *ExpectedTDigestInfo
+	ExpectTDigestMax(key string) *ExpectedFloat
+	ExpectTDigestMin(key string) *ExpectedFloat
+	ExpectTDigestMerge(destKey string, options *redis.TDigestMergeOptions, sourceKeys ...string) *ExpectedStatus
+	ExpectTDigestQuantile(key string, elements ...float64) *ExpectedFloatSlice
+	ExpectTDigestRank(key string, values ...float64) *ExpectedIntSlice
+	ExpectTDigestReset(key string) *ExpectedStatus
+	ExpectTDigestRevRank(key string, values ...float64) *ExpectedIntSlice
+	ExpectTDigestTrimmedMean(key string, lowCutQuantile, highCutQuantile float64) *ExpectedFloat
+	ExpectTSAdd(key string, timestamp interface{}, value float64) *ExpectedInt
+	ExpectTSAddWithArgs(key string, timestamp interface{}, value float64, options *redis.TSOptions) *ExpectedInt
+	ExpectTSCreate(key string) *ExpectedStatus
+	ExpectTSCreateWithArgs(key string, options *redis.TSOptions) *ExpectedStatus
+	ExpectTSAlter(key string, options *redis.TSAlterOptions) *ExpectedStatus
+	ExpectTSCreateRule(sourceKey string, destKey string, aggregator redis.Aggregator, bucketDuration int) *ExpectedStatus
+	ExpectTSCreateRuleWithArgs(sourceKey string, destKey string, aggregator redis.Aggregator, bucketDuration int, options *redis.TSCreateRuleOptions) *ExpectedStatus
+	ExpectTSIncrBy(key string, timestamp float64) *ExpectedInt
+	ExpectTSIncrByWithArgs(key string, timestamp float64, options *redis.TSIncrDecrOptions) *ExpectedInt
+	ExpectTSDecrBy(key string, timestamp float64) *ExpectedInt
+	ExpectTSDecrByWithArgs(key string, timestamp float64, options *redis.TSIncrDecrOptions) *ExpectedInt
+	ExpectTSDel(key string, fromTimestamp int, toTimestamp int) *ExpectedInt
+	ExpectTSDeleteRule(sourceKey string, destKey string) *ExpectedStatus
+	ExpectTSGet(key string) *ExpectedTSTimestampValue
+	ExpectTSGetWithArgs(key string, options *redis.TSGetOptions) *ExpectedTSTimestampValue
+	ExpectTSInfo(key string) *ExpectedMapStringInterface
+	ExpectTSInfoWithArgs(key string, options *redis.TSInfoOptions) *ExpectedMapStringInterface
+	ExpectTSMAdd(ktvSlices [][]interface{}) *ExpectedIntSlice
+	ExpectTSQueryIndex(filterExpr []string) *ExpectedStringSlice
+	ExpectTSRevRange(key string, fromTimestamp int, toTimestamp int) *ExpectedTSTimestampValueSlice
+	ExpectTSRevRangeWithArgs(key string, fromTimestamp int, toTimestamp int, options *redis.TSRevRangeOptions) *ExpectedTSTimestampValueSlice
+	ExpectTSRange(key string, fromTimestamp int, toTimestamp int) *ExpectedTSTimestampValueSlice
+	ExpectTSRangeWithArgs(key string, fromTimestamp int, toTimestamp int, options *redis.TSRangeOptions) *ExpectedTSTimestampValueSlice
+	ExpectTSMRange(fromTimestamp int, toTimestamp int, filterExpr []string) *ExpectedMapStringSliceInterface
+	ExpectTSMRangeWithArgs(fromTimestamp int, toTimestamp int, filterExpr []string, options *redis.TSMRangeOptions) *ExpectedMapStringSliceInterface
+	ExpectTSMRevRange(fromTimestamp int, toTimestamp int, filterExpr []string) *ExpectedMapStringSliceInterface
+	ExpectTSMRevRangeWithArgs(fromTimestamp int, toTimestamp int, filterExpr []string, options *redis.TSMRevRangeOptions) *ExpectedMapStringSliceInterface
+	ExpectTSMGet(filters []string) *ExpectedMapStringSliceInterface
+	ExpectTSMGetWithArgs(filters []string, options *redis.TSMGetOptions) *ExpectedMapStringSliceInterface
+	ExpectGetBit(key string, offset int64) *ExpectedInt
+	ExpectSetBit(key string, offset int64, value int) *ExpectedInt
+	ExpectBitCount(key string, bitCount *redis.BitCount) *ExpectedInt
+	ExpectBitOpAnd(destKey string, keys ...string) *ExpectedInt
+	ExpectBitOpOr(destKey string, keys ...string) *ExpectedInt
+	ExpectBitOpXor(destKey string, keys ...string) *ExpectedInt
+	ExpectBitOpNot(destKey string, key string) *ExpectedInt
+	ExpectBitPos(key string, bit int64, pos ...int64) *ExpectedInt
+	ExpectBitPosSpan(key string, bit int8, start, end int64, span string) *ExpectedInt
+	ExpectBitField(key string, values ...interface{}) *ExpectedIntSlice
+	ExpectXAdd(a *redis.XAddArgs) *ExpectedString
+	ExpectXDel(stream string, ids ...string) *ExpectedInt
+	ExpectXLen(stream string) *ExpectedInt
+	ExpectXRange(stream, start, stop string) *ExpectedXMessageSlice
+	ExpectXRangeN(stream, start, stop string, count int64) *ExpectedXMessageSlice
+	ExpectXRevRange(stream string, start, stop string) *ExpectedXMessageSlice
+	ExpectXRevRangeN(stream string, start, stop string, count int64) *ExpectedXMessageSlice
+	ExpectXRead(a *redis.XReadArgs) *ExpectedXStreamSlice
+	ExpectXReadStreams(streams ...string) *ExpectedXStreamSlice
+	ExpectXGroupCreate(stream, group, start string) *ExpectedStatus
+	ExpectXGroupCreateMkStream(stream, group, start string) *ExpectedStatus
+	ExpectXGroupSetID(stream, group, start string) *ExpectedStatus
+	ExpectXGroupDestroy(stream, group string) *ExpectedInt
+	ExpectXGroupCreateConsumer(stream, group, consumer string) *ExpectedInt
+	ExpectXGroupDelConsumer(stream, group, consumer string) *ExpectedInt
+	ExpectXReadGroup(a *redis.XReadGroupArgs) *ExpectedXStreamSlice
+	ExpectXAck(stream, group string, ids ...string) *ExpectedInt
+	ExpectXPending(stream, group string) *ExpectedXPending
+	ExpectXPendingExt(a *redis.XPendingExtArgs) *ExpectedXPendingExt
+	ExpectXClaim(a *redis.XClaimArgs) *ExpectedXMessageSlice
+	ExpectXClaimJustID(a *redis.XClaimArgs) *ExpectedStringSlice
+	ExpectXAutoClaim(a *redis.XAutoClaimArgs) *ExpectedXAutoClaim
+	ExpectXAutoClaimJustID(a *redis.XAutoClaimArgs) *ExpectedXAutoClaimJustID
+	ExpectXTrimMaxLen(key string, maxLen int64) *ExpectedInt
+	ExpectXTrimMaxLenApprox(key string, maxLen, limit int64) *ExpectedInt
+	ExpectXTrimMinID(key string, minID string) *ExpectedInt
+	ExpectXTrimMinIDApprox(key string, minID string, limit int64) *ExpectedInt
+	ExpectXInfoGroups(key string) *ExpectedXInfoGroups
+	ExpectXInfoStream(key string) *ExpectedXInfoStream
+	ExpectXInfoStreamFull(key string, count int) *ExpectedXInfoStreamFull
+	ExpectXInfoConsumers(key string, group string) *ExpectedXInfoConsumers
+}
+
+func (m *mock) ExpectCommand() *ExpectedCommandsInfo {
+	e := &ExpectedCommandsInfo{}
+	e.cmd = m.factory.Command(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCommandList(filter *redis.FilterBy) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.CommandList(m.ctx, filter)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCommandGetKeys(commands ...interface{}) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.CommandGetKeys(m.ctx, commands...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCommandGetKeysAndFlags(commands ...interface{}) *ExpectedKeyFlags {
+	e := &ExpectedKeyFlags{}
+	e.cmd = m.factory.CommandGetKeysAndFlags(m.ctx, commands...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientGetName() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ClientGetName(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectEcho(message interface{}) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.Echo(m.ctx, message)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPing() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Ping(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectQuit() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Quit(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectUnlink(keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Unlink(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBgRewriteAOF() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.BgRewriteAOF(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBgSave() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.BgSave(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientKill(ipPort string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClientKill(m.ctx, ipPort)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientKillByFilter(keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ClientKillByFilter(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientList() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ClientList(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientInfo() *ExpectedClientInfo {
+	e := &ExpectedClientInfo{}
+	e.cmd = m.factory.ClientInfo(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientPause(dur time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.ClientPause(m.ctx, dur)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientUnpause() *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.ClientUnpause(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientID() *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ClientID(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientUnblock(id int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ClientUnblock(m.ctx, id)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClientUnblockWithError(id int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ClientUnblockWithError(m.ctx, id)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectConfigGet(parameter string) *ExpectedMapStringString {
+	e := &ExpectedMapStringString{}
+	e.cmd = m.factory.ConfigGet(m.ctx, parameter)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectConfigResetStat() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ConfigResetStat(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectConfigSet(parameter, value string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ConfigSet(m.ctx, parameter, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectConfigRewrite() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ConfigRewrite(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectDBSize() *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.DBSize(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFlushAll() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.FlushAll(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFlushAllAsync() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.FlushAllAsync(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFlushDB() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.FlushDB(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFlushDBAsync() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.FlushDBAsync(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectInfo(section ...string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.Info(m.ctx, section...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLastSave() *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LastSave(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSave() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Save(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectShutdown() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Shutdown(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectShutdownSave() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ShutdownSave(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectShutdownNoSave() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ShutdownNoSave(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSlaveOf(host, port string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.SlaveOf(m.ctx, host, port)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSlowLogGet(num int64) *ExpectedSlowLog {
+	e := &ExpectedSlowLog{}
+	e.cmd = m.factory.SlowLogGet(m.ctx, num)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTime() *ExpectedTime {
+	e := &ExpectedTime{}
+	e.cmd = m.factory.Time(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectDebugObject(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.DebugObject(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectMemoryUsage(key string, samples ...int) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.MemoryUsage(m.ctx, key, samples...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectModuleLoadex(conf *redis.ModuleLoadexConfig) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ModuleLoadex(m.ctx, conf)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectACLDryRun(username string, command ...interface{}) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ACLDryRun(m.ctx, username, command...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectACLLog(count int64) *ExpectedACLLog {
+	e := &ExpectedACLLog{}
+	e.cmd = m.factory.ACLLog(m.ctx, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectACLLogReset() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ACLLogReset(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHDel(key string, fields ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.HDel(m.ctx, key, fields...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHExists(key, field string) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.HExists(m.ctx, key, field)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHGet(key, field string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.HGet(m.ctx, key, field)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHGetAll(key string) *ExpectedMapStringString {
+	e := &ExpectedMapStringString{}
+	e.cmd = m.factory.HGetAll(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHIncrBy(key, field string, incr int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.HIncrBy(m.ctx, key, field, incr)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHIncrByFloat(key, field string, incr float64) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.HIncrByFloat(m.ctx, key, field, incr)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHKeys(key string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.HKeys(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHLen(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.HLen(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHMGet(key string, fields ...string) *ExpectedSlice {
+	e := &ExpectedSlice{}
+	e.cmd = m.factory.HMGet(m.ctx, key, fields...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHSet(key string, values ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.HSet(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHMSet(key string, values ...interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.HMSet(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHSetNX(key, field string, value interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.HSetNX(m.ctx, key, field, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHScan(key string, cursor uint64, match string, count int64) *ExpectedScan {
+	e := &ExpectedScan{}
+	e.cmd = m.factory.HScan(m.ctx, key, cursor, match, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHVals(key string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.HVals(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHRandField(key string, count int) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.HRandField(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectHRandFieldWithValues(key string, count int) *ExpectedKeyValueSlice {
+	e := &ExpectedKeyValueSlice{}
+	e.cmd = m.factory.HRandFieldWithValues(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPFAdd(key string, els ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.PFAdd(m.ctx, key, els...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPFCount(keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.PFCount(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPFMerge(dest string, keys ...string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.PFMerge(m.ctx, dest, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoAdd(key string, geoLocation ...*redis.GeoLocation) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.GeoAdd(m.ctx, key, geoLocation...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoPos(key string, members ...string) *ExpectedGeoPos {
+	e := &ExpectedGeoPos{}
+	e.cmd = m.factory.GeoPos(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoRadius(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *ExpectedGeoLocation {
+	e := &ExpectedGeoLocation{}
+	e.cmd = m.factory.GeoRadius(m.ctx, key, longitude, latitude, query)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoRadiusStore(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.GeoRadiusStore(m.ctx, key, longitude, latitude, query)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoRadiusByMember(key, member string, query *redis.GeoRadiusQuery) *ExpectedGeoLocation {
+	e := &ExpectedGeoLocation{}
+	e.cmd = m.factory.GeoRadiusByMember(m.ctx, key, member, query)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoRadiusByMemberStore(key, member string, query *redis.GeoRadiusQuery) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.GeoRadiusByMemberStore(m.ctx, key, member, query)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoSearch(key string, q *redis.GeoSearchQuery) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.GeoSearch(m.ctx, key, q)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoSearchLocation(key string, q *redis.GeoSearchLocationQuery) *ExpectedGeoSearchLocation {
+	e := &ExpectedGeoSearchLocation{}
+	e.cmd = m.factory.GeoSearchLocation(m.ctx, key, q)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoSearchStore(key, store string, q *redis.GeoSearchStoreQuery) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.GeoSearchStore(m.ctx, key, store, q)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoDist(key string, member1, member2, unit string) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.GeoDist(m.ctx, key, member1, member2, unit)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGeoHash(key string, members ...string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.GeoHash(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectDel(keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Del(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectDump(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.Dump(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExists(keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Exists(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExpire(key string, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.Expire(m.ctx, key, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExpireAt(key string, tm time.Time) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.ExpireAt(m.ctx, key, tm)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExpireTime(key string) *ExpectedDuration {
+	e := &ExpectedDuration{}
+	e.cmd = m.factory.ExpireTime(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExpireNX(key string, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.ExpireNX(m.ctx, key, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExpireXX(key string, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.ExpireXX(m.ctx, key, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExpireGT(key string, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.ExpireGT(m.ctx, key, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectExpireLT(key string, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.ExpireLT(m.ctx, key, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectKeys(pattern string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.Keys(m.ctx, pattern)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectMigrate(host, port, key string, db int, timeout time.Duration) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Migrate(m.ctx, host, port, key, db, timeout)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectMove(key string, db int) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.Move(m.ctx, key, db)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectObjectRefCount(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ObjectRefCount(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectObjectEncoding(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ObjectEncoding(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectObjectIdleTime(key string) *ExpectedDuration {
+	e := &ExpectedDuration{}
+	e.cmd = m.factory.ObjectIdleTime(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPersist(key string) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.Persist(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPExpire(key string, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.PExpire(m.ctx, key, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPExpireAt(key string, tm time.Time) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.PExpireAt(m.ctx, key, tm)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPExpireTime(key string) *ExpectedDuration {
+	e := &ExpectedDuration{}
+	e.cmd = m.factory.PExpireTime(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPTTL(key string) *ExpectedDuration {
+	e := &ExpectedDuration{}
+	e.cmd = m.factory.PTTL(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRandomKey() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.RandomKey(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRename(key, newkey string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Rename(m.ctx, key, newkey)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRenameNX(key, newkey string) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.RenameNX(m.ctx, key, newkey)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRestore(key string, ttl time.Duration, value string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Restore(m.ctx, key, ttl, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRestoreReplace(key string, ttl time.Duration, value string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.RestoreReplace(m.ctx, key, ttl, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSort(key string, sort *redis.Sort) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.Sort(m.ctx, key, sort)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSortRO(key string, sort *redis.Sort) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.SortRO(m.ctx, key, sort)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSortStore(key, store string, sort *redis.Sort) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SortStore(m.ctx, key, store, sort)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSortInterfaces(key string, sort *redis.Sort) *ExpectedSlice {
+	e := &ExpectedSlice{}
+	e.cmd = m.factory.SortInterfaces(m.ctx, key, sort)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTouch(keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Touch(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTTL(key string) *ExpectedDuration {
+	e := &ExpectedDuration{}
+	e.cmd = m.factory.TTL(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectType(key string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Type(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCopy(sourceKey string, destKey string, db int, replace bool) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Copy(m.ctx, sourceKey, destKey, db, replace)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectScan(cursor uint64, match string, count int64) *ExpectedScan {
+	e := &ExpectedScan{}
+	e.cmd = m.factory.Scan(m.ctx, cursor, match, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectScanType(cursor uint64, match string, count int64, keyType string) *ExpectedScan {
+	e := &ExpectedScan{}
+	e.cmd = m.factory.ScanType(m.ctx, cursor, match, count, keyType)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBLPop(timeout time.Duration, keys ...string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.BLPop(m.ctx, timeout, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBLMPop(timeout time.Duration, direction string, count int64, keys ...string) *ExpectedKeyValues {
+	e := &ExpectedKeyValues{}
+	e.cmd = m.factory.BLMPop(m.ctx, timeout, direction, count, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBRPop(timeout time.Duration, keys ...string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.BRPop(m.ctx, timeout, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBRPopLPush(source, destination string, timeout time.Duration) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.BRPopLPush(m.ctx, source, destination, timeout)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLIndex(key string, index int64) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.LIndex(m.ctx, key, index)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLInsert(key, op string, pivot, value interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LInsert(m.ctx, key, op, pivot, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLInsertBefore(key string, pivot, value interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LInsertBefore(m.ctx, key, pivot, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLInsertAfter(key string, pivot, value interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LInsertAfter(m.ctx, key, pivot, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLLen(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LLen(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLMPop(direction string, count int64, keys ...string) *ExpectedKeyValues {
+	e := &ExpectedKeyValues{}
+	e.cmd = m.factory.LMPop(m.ctx, direction, count, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLPop(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.LPop(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLPopCount(key string, count int) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.LPopCount(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLPos(key string, value string, args redis.LPosArgs) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LPos(m.ctx, key, value, args)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLPosCount(key string, value string, count int64, args redis.LPosArgs) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.LPosCount(m.ctx, key, value, count, args)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLPush(key string, values ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LPush(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLPushX(key string, values ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LPushX(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLRange(key string, start, stop int64) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.LRange(m.ctx, key, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLRem(key string, count int64, value interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.LRem(m.ctx, key, count, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLSet(key string, index int64, value interface{}) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.LSet(m.ctx, key, index, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLTrim(key string, start, stop int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.LTrim(m.ctx, key, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRPop(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.RPop(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRPopCount(key string, count int) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.RPopCount(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRPopLPush(source, destination string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.RPopLPush(m.ctx, source, destination)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRPush(key string, values ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.RPush(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectRPushX(key string, values ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.RPushX(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLMove(source, destination, srcpos, destpos string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.LMove(m.ctx, source, destination, srcpos, destpos)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBLMove(source, destination, srcpos, destpos string, timeout time.Duration) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.BLMove(m.ctx, source, destination, srcpos, destpos, timeout)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSAdd(key string, members ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SAdd(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSCard(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SCard(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSDiff(keys ...string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.SDiff(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSDiffStore(destination string, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SDiffStore(m.ctx, destination, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSInter(keys ...string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.SInter(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSInterCard(limit int64, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SInterCard(m.ctx, limit, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSInterStore(destination string, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SInterStore(m.ctx, destination, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSIsMember(key string, member interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.SIsMember(m.ctx, key, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSMIsMember(key string, members ...interface{}) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.SMIsMember(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSMembers(key string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.SMembers(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSMembersMap(key string) *ExpectedStringStructMap {
+	e := &ExpectedStringStructMap{}
+	e.cmd = m.factory.SMembersMap(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSMove(source, destination string, member interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.SMove(m.ctx, source, destination, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSPop(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.SPop(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSPopN(key string, count int64) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.SPopN(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSRandMember(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.SRandMember(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSRandMemberN(key string, count int64) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.SRandMemberN(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSRem(key string, members ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SRem(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSScan(key string, cursor uint64, match string, count int64) *ExpectedScan {
+	e := &ExpectedScan{}
+	e.cmd = m.factory.SScan(m.ctx, key, cursor, match, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSUnion(keys ...string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.SUnion(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSUnionStore(destination string, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SUnionStore(m.ctx, destination, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBZPopMax(timeout time.Duration, keys ...string) *ExpectedZWithKey {
+	e := &ExpectedZWithKey{}
+	e.cmd = m.factory.BZPopMax(m.ctx, timeout, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBZPopMin(timeout time.Duration, keys ...string) *ExpectedZWithKey {
+	e := &ExpectedZWithKey{}
+	e.cmd = m.factory.BZPopMin(m.ctx, timeout, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBZMPop(timeout time.Duration, order string, count int64, keys ...string) *ExpectedZSliceWithKey {
+	e := &ExpectedZSliceWithKey{}
+	e.cmd = m.factory.BZMPop(m.ctx, timeout, order, count, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZAdd(key string, members ...redis.Z) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZAdd(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZAddLT(key string, members ...redis.Z) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZAddLT(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZAddGT(key string, members ...redis.Z) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZAddGT(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZAddNX(key string, members ...redis.Z) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZAddNX(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZAddXX(key string, members ...redis.Z) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZAddXX(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZAddArgs(key string, args redis.ZAddArgs) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZAddArgs(m.ctx, key, args)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZAddArgsIncr(key string, args redis.ZAddArgs) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.ZAddArgsIncr(m.ctx, key, args)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZCard(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZCard(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZCount(key, min, max string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZCount(m.ctx, key, min, max)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZLexCount(key, min, max string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZLexCount(m.ctx, key, min, max)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZIncrBy(key string, increment float64, member string) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.ZIncrBy(m.ctx, key, increment, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZInter(store *redis.ZStore) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZInter(m.ctx, store)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZInterWithScores(store *redis.ZStore) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZInterWithScores(m.ctx, store)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZInterCard(limit int64, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZInterCard(m.ctx, limit, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZInterStore(destination string, store *redis.ZStore) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZInterStore(m.ctx, destination, store)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZMPop(order string, count int64, keys ...string) *ExpectedZSliceWithKey {
+	e := &ExpectedZSliceWithKey{}
+	e.cmd = m.factory.ZMPop(m.ctx, order, count, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZMScore(key string, members ...string) *ExpectedFloatSlice {
+	e := &ExpectedFloatSlice{}
+	e.cmd = m.factory.ZMScore(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZPopMax(key string, count ...int64) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZPopMax(m.ctx, key, count...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZPopMin(key string, count ...int64) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZPopMin(m.ctx, key, count...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRange(key string, start, stop int64) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRange(m.ctx, key, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRangeWithScores(key string, start, stop int64) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZRangeWithScores(m.ctx, key, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRangeByScore(key string, opt *redis.ZRangeBy) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRangeByScore(m.ctx, key, opt)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRangeByLex(key string, opt *redis.ZRangeBy) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRangeByLex(m.ctx, key, opt)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRangeByScoreWithScores(key string, opt *redis.ZRangeBy) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZRangeByScoreWithScores(m.ctx, key, opt)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRangeArgs(z redis.ZRangeArgs) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRangeArgs(m.ctx, z)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRangeArgsWithScores(z redis.ZRangeArgs) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZRangeArgsWithScores(m.ctx, z)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRangeStore(dst string, z redis.ZRangeArgs) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZRangeStore(m.ctx, dst, z)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRank(key, member string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZRank(m.ctx, key, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRankWithScore(key, member string) *ExpectedRankWithScore {
+	e := &ExpectedRankWithScore{}
+	e.cmd = m.factory.ZRankWithScore(m.ctx, key, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRem(key string, members ...interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZRem(m.ctx, key, members...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRemRangeByRank(key string, start, stop int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZRemRangeByRank(m.ctx, key, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRemRangeByScore(key, min, max string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZRemRangeByScore(m.ctx, key, min, max)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRemRangeByLex(key, min, max string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZRemRangeByLex(m.ctx, key, min, max)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRevRange(key string, start, stop int64) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRevRange(m.ctx, key, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRevRangeWithScores(key string, start, stop int64) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZRevRangeWithScores(m.ctx, key, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRevRangeByScore(key string, opt *redis.ZRangeBy) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRevRangeByScore(m.ctx, key, opt)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRevRangeByLex(key string, opt *redis.ZRangeBy) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRevRangeByLex(m.ctx, key, opt)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRevRangeByScoreWithScores(key string, opt *redis.ZRangeBy) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZRevRangeByScoreWithScores(m.ctx, key, opt)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRevRank(key, member string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZRevRank(m.ctx, key, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRevRankWithScore(key, member string) *ExpectedRankWithScore {
+	e := &ExpectedRankWithScore{}
+	e.cmd = m.factory.ZRevRankWithScore(m.ctx, key, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZScore(key, member string) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.ZScore(m.ctx, key, member)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZUnionStore(dest string, store *redis.ZStore) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZUnionStore(m.ctx, dest, store)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRandMember(key string, count int) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZRandMember(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZRandMemberWithScores(key string, count int) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZRandMemberWithScores(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZUnion(store redis.ZStore) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZUnion(m.ctx, store)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZUnionWithScores(store redis.ZStore) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZUnionWithScores(m.ctx, store)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZDiff(keys ...string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ZDiff(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZDiffWithScores(keys ...string) *ExpectedZSlice {
+	e := &ExpectedZSlice{}
+	e.cmd = m.factory.ZDiffWithScores(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZDiffStore(destination string, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ZDiffStore(m.ctx, destination, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectZScan(key string, cursor uint64, match string, count int64) *ExpectedScan {
+	e := &ExpectedScan{}
+	e.cmd = m.factory.ZScan(m.ctx, key, cursor, match, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterMyShardID() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ClusterMyShardID(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterSlots() *ExpectedClusterSlots {
+	e := &ExpectedClusterSlots{}
+	e.cmd = m.factory.ClusterSlots(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterShards() *ExpectedClusterShards {
+	e := &ExpectedClusterShards{}
+	e.cmd = m.factory.ClusterShards(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterLinks() *ExpectedClusterLinks {
+	e := &ExpectedClusterLinks{}
+	e.cmd = m.factory.ClusterLinks(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterNodes() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ClusterNodes(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterMeet(host, port string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterMeet(m.ctx, host, port)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterForget(nodeID string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterForget(m.ctx, nodeID)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterReplicate(nodeID string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterReplicate(m.ctx, nodeID)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterResetSoft() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterResetSoft(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterResetHard() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterResetHard(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterInfo() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ClusterInfo(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterKeySlot(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ClusterKeySlot(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterGetKeysInSlot(slot int, count int) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ClusterGetKeysInSlot(m.ctx, slot, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterCountFailureReports(nodeID string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ClusterCountFailureReports(m.ctx, nodeID)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterCountKeysInSlot(slot int) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.ClusterCountKeysInSlot(m.ctx, slot)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterDelSlots(slots ...int) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterDelSlots(m.ctx, slots...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterDelSlotsRange(min, max int) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterDelSlotsRange(m.ctx, min, max)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterSaveConfig() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterSaveConfig(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterSlaves(nodeID string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.ClusterSlaves(m.ctx, nodeID)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterFailover() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterFailover(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterAddSlots(slots ...int) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterAddSlots(m.ctx, slots...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectClusterAddSlotsRange(min, max int) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ClusterAddSlotsRange(m.ctx, min, max)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectReadOnly() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ReadOnly(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectReadWrite() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ReadWrite(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectEval(script string, keys []string, args ...interface{}) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.Eval(m.ctx, script, keys, args...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectEvalSha(sha1 string, keys []string, args ...interface{}) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.EvalSha(m.ctx, sha1, keys, args...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectEvalRO(script string, keys []string, args ...interface{}) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.EvalRO(m.ctx, script, keys, args...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectEvalShaRO(sha1 string, keys []string, args ...interface{}) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.EvalShaRO(m.ctx, sha1, keys, args...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectScriptExists(hashes ...string) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.ScriptExists(m.ctx, hashes...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectScriptFlush() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ScriptFlush(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectScriptKill() *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.ScriptKill(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectScriptLoad(script string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.ScriptLoad(m.ctx, script)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionLoad(code string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionLoad(m.ctx, code)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionLoadReplace(code string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionLoadReplace(m.ctx, code)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionDelete(libName string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionDelete(m.ctx, libName)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionFlush() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionFlush(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionKill() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionKill(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionFlushAsync() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionFlushAsync(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionList(q redis.FunctionListQuery) *ExpectedFunctionList {
+	e := &ExpectedFunctionList{}
+	e.cmd = m.factory.FunctionList(m.ctx, q)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionDump() *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionDump(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionRestore(libDump string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.FunctionRestore(m.ctx, libDump)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFunctionStats() *ExpectedFunctionStats {
+	e := &ExpectedFunctionStats{}
+	e.cmd = m.factory.FunctionStats(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFCall(function string, keys []string, args ...interface{}) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.FCall(m.ctx, function, keys, args...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFCallRo(function string, keys []string, args ...interface{}) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.FCallRo(m.ctx, function, keys, args...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectFCallRO(function string, keys []string, args ...interface{}) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.FCallRO(m.ctx, function, keys, args...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectAppend(key, value string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Append(m.ctx, key, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectDecr(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Decr(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectDecrBy(key string, decrement int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.DecrBy(m.ctx, key, decrement)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGet(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.Get(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGetRange(key string, start, end int64) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.GetRange(m.ctx, key, start, end)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGetSet(key string, value interface{}) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.GetSet(m.ctx, key, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGetEx(key string, expiration time.Duration) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.GetEx(m.ctx, key, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGetDel(key string) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.GetDel(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectIncr(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Incr(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectIncrBy(key string, value int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.IncrBy(m.ctx, key, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectIncrByFloat(key string, value float64) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.IncrByFloat(m.ctx, key, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectLCS(q *redis.LCSQuery) *ExpectedLCS {
+	e := &ExpectedLCS{}
+	e.cmd = m.factory.LCS(m.ctx, q)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectMGet(keys ...string) *ExpectedSlice {
+	e := &ExpectedSlice{}
+	e.cmd = m.factory.MGet(m.ctx, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectMSet(values ...interface{}) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.MSet(m.ctx, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectMSetNX(values ...interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.MSetNX(m.ctx, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSet(key string, value interface{}, expiration time.Duration) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.Set(m.ctx, key, value, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSetArgs(key string, value interface{}, a redis.SetArgs) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.SetArgs(m.ctx, key, value, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSetEx(key string, value interface{}, expiration time.Duration) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.SetEx(m.ctx, key, value, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSetNX(key string, value interface{}, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.SetNX(m.ctx, key, value, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSetXX(key string, value interface{}, expiration time.Duration) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.SetXX(m.ctx, key, value, expiration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSetRange(key string, offset int64, value string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SetRange(m.ctx, key, offset, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectStrLen(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.StrLen(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPublish(channel string, message interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.Publish(m.ctx, channel, message)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSPublish(channel string, message interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SPublish(m.ctx, channel, message)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPubSubChannels(pattern string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.PubSubChannels(m.ctx, pattern)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPubSubNumSub(channels ...string) *ExpectedMapStringInt {
+	e := &ExpectedMapStringInt{}
+	e.cmd = m.factory.PubSubNumSub(m.ctx, channels...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPubSubNumPat() *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.PubSubNumPat(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPubSubShardChannels(pattern string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.PubSubShardChannels(m.ctx, pattern)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectPubSubShardNumSub(channels ...string) *ExpectedMapStringInt {
+	e := &ExpectedMapStringInt{}
+	e.cmd = m.factory.PubSubShardNumSub(m.ctx, channels...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFunctionLoad(lib string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TFunctionLoad(m.ctx, lib)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFunctionLoadArgs(lib string, options *redis.TFunctionLoadOptions) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TFunctionLoadArgs(m.ctx, lib, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFunctionDelete(libName string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TFunctionDelete(m.ctx, libName)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFunctionList() *ExpectedMapStringInterfaceSlice {
+	e := &ExpectedMapStringInterfaceSlice{}
+	e.cmd = m.factory.TFunctionList(m.ctx)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFunctionListArgs(options *redis.TFunctionListOptions) *ExpectedMapStringInterfaceSlice {
+	e := &ExpectedMapStringInterfaceSlice{}
+	e.cmd = m.factory.TFunctionListArgs(m.ctx, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFCall(libName string, funcName string, numKeys int) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.TFCall(m.ctx, libName, funcName, numKeys)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFCallArgs(libName string, funcName string, numKeys int, options *redis.TFCallOptions) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.TFCallArgs(m.ctx, libName, funcName, numKeys, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFCallASYNC(libName string, funcName string, numKeys int) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.TFCallASYNC(m.ctx, libName, funcName, numKeys)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTFCallASYNCArgs(libName string, funcName string, numKeys int, options *redis.TFCallOptions) *ExpectedCmd {
+	e := &ExpectedCmd{}
+	e.cmd = m.factory.TFCallASYNCArgs(m.ctx, libName, funcName, numKeys, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFAdd(key string, element interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.BFAdd(m.ctx, key, element)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFCard(key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BFCard(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFExists(key string, element interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.BFExists(m.ctx, key, element)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInfo(key string) *ExpectedBFInfo {
+	e := &ExpectedBFInfo{}
+	e.cmd = m.factory.BFInfo(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInfoArg(key, option string) *ExpectedBFInfo {
+	e := &ExpectedBFInfo{}
+	e.cmd = m.factory.BFInfoArg(m.ctx, key, option)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInfoCapacity(key string) *ExpectedBFInfo {
+	e := &ExpectedBFInfo{}
+	e.cmd = m.factory.BFInfoCapacity(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInfoSize(key string) *ExpectedBFInfo {
+	e := &ExpectedBFInfo{}
+	e.cmd = m.factory.BFInfoSize(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInfoFilters(key string) *ExpectedBFInfo {
+	e := &ExpectedBFInfo{}
+	e.cmd = m.factory.BFInfoFilters(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInfoItems(key string) *ExpectedBFInfo {
+	e := &ExpectedBFInfo{}
+	e.cmd = m.factory.BFInfoItems(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInfoExpansion(key string) *ExpectedBFInfo {
+	e := &ExpectedBFInfo{}
+	e.cmd = m.factory.BFInfoExpansion(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFInsert(key string, options *redis.BFInsertOptions, elements ...interface{}) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.BFInsert(m.ctx, key, options, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFMAdd(key string, elements ...interface{}) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.BFMAdd(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFMExists(key string, elements ...interface{}) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.BFMExists(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFReserve(key string, errorRate float64, capacity int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.BFReserve(m.ctx, key, errorRate, capacity)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFReserveExpansion(key string, errorRate float64, capacity, expansion int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.BFReserveExpansion(m.ctx, key, errorRate, capacity, expansion)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFReserveNonScaling(key string, errorRate float64, capacity int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.BFReserveNonScaling(m.ctx, key, errorRate, capacity)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFReserveWithArgs(key string, options *redis.BFReserveOptions) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.BFReserveWithArgs(m.ctx, key, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFScanDump(key string, iterator int64) *ExpectedScanDump {
+	e := &ExpectedScanDump{}
+	e.cmd = m.factory.BFScanDump(m.ctx, key, iterator)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBFLoadChunk(key string, iterator int64, data interface{}) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.BFLoadChunk(m.ctx, key, iterator, data)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFAdd(key string, element interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.CFAdd(m.ctx, key, element)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFAddNX(key string, element interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.CFAddNX(m.ctx, key, element)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFCount(key string, element interface{}) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.CFCount(m.ctx, key, element)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFDel(key string, element interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.CFDel(m.ctx, key, element)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFExists(key string, element interface{}) *ExpectedBool {
+	e := &ExpectedBool{}
+	e.cmd = m.factory.CFExists(m.ctx, key, element)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFInfo(key string) *ExpectedCFInfo {
+	e := &ExpectedCFInfo{}
+	e.cmd = m.factory.CFInfo(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFInsert(key string, options *redis.CFInsertOptions, elements ...interface{}) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.CFInsert(m.ctx, key, options, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFInsertNX(key string, options *redis.CFInsertOptions, elements ...interface{}) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.CFInsertNX(m.ctx, key, options, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFMExists(key string, elements ...interface{}) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.CFMExists(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFReserve(key string, capacity int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CFReserve(m.ctx, key, capacity)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFReserveWithArgs(key string, options *redis.CFReserveOptions) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CFReserveWithArgs(m.ctx, key, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFReserveExpansion(key string, capacity int64, expansion int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CFReserveExpansion(m.ctx, key, capacity, expansion)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFReserveBucketSize(key string, capacity int64, bucketsize int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CFReserveBucketSize(m.ctx, key, capacity, bucketsize)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFReserveMaxIterations(key string, capacity int64, maxiterations int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CFReserveMaxIterations(m.ctx, key, capacity, maxiterations)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFScanDump(key string, iterator int64) *ExpectedScanDump {
+	e := &ExpectedScanDump{}
+	e.cmd = m.factory.CFScanDump(m.ctx, key, iterator)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCFLoadChunk(key string, iterator int64, data interface{}) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CFLoadChunk(m.ctx, key, iterator, data)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCMSIncrBy(key string, elements ...interface{}) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.CMSIncrBy(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCMSInfo(key string) *ExpectedCMSInfo {
+	e := &ExpectedCMSInfo{}
+	e.cmd = m.factory.CMSInfo(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCMSInitByDim(key string, width, height int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CMSInitByDim(m.ctx, key, width, height)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCMSInitByProb(key string, errorRate, probability float64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CMSInitByProb(m.ctx, key, errorRate, probability)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCMSMerge(destKey string, sourceKeys ...string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CMSMerge(m.ctx, destKey, sourceKeys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCMSMergeWithWeight(destKey string, sourceKeys map[string]int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.CMSMergeWithWeight(m.ctx, destKey, sourceKeys)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectCMSQuery(key string, elements ...interface{}) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.CMSQuery(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKAdd(key string, elements ...interface{}) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.TopKAdd(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKCount(key string, elements ...interface{}) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.TopKCount(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKIncrBy(key string, elements ...interface{}) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.TopKIncrBy(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKInfo(key string) *ExpectedTopKInfo {
+	e := &ExpectedTopKInfo{}
+	e.cmd = m.factory.TopKInfo(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKList(key string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.TopKList(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKListWithCount(key string) *ExpectedMapStringInt {
+	e := &ExpectedMapStringInt{}
+	e.cmd = m.factory.TopKListWithCount(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKQuery(key string, elements ...interface{}) *ExpectedBoolSlice {
+	e := &ExpectedBoolSlice{}
+	e.cmd = m.factory.TopKQuery(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKReserve(key string, k int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TopKReserve(m.ctx, key, k)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTopKReserveWithOptions(key string, k int64, width, depth int64, decay float64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TopKReserveWithOptions(m.ctx, key, k, width, depth, decay)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestAdd(key string, elements ...float64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TDigestAdd(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestByRank(key string, rank ...uint64) *ExpectedFloatSlice {
+	e := &ExpectedFloatSlice{}
+	e.cmd = m.factory.TDigestByRank(m.ctx, key, rank...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestByRevRank(key string, rank ...uint64) *ExpectedFloatSlice {
+	e := &ExpectedFloatSlice{}
+	e.cmd = m.factory.TDigestByRevRank(m.ctx, key, rank...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestCDF(key string, elements ...float64) *ExpectedFloatSlice {
+	e := &ExpectedFloatSlice{}
+	e.cmd = m.factory.TDigestCDF(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestCreate(key string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TDigestCreate(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestCreateWithCompression(key string, compression int64) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TDigestCreateWithCompression(m.ctx, key, compression)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestInfo(key string) *ExpectedTDigestInfo {
+	e := &ExpectedTDigestInfo{}
+	e.cmd = m.factory.TDigestInfo(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestMax(key string) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.TDigestMax(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestMin(key string) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.TDigestMin(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestMerge(destKey string, options *redis.TDigestMergeOptions, sourceKeys ...string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TDigestMerge(m.ctx, destKey, options, sourceKeys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestQuantile(key string, elements ...float64) *ExpectedFloatSlice {
+	e := &ExpectedFloatSlice{}
+	e.cmd = m.factory.TDigestQuantile(m.ctx, key, elements...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestRank(key string, values ...float64) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.TDigestRank(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestReset(key string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TDigestReset(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestRevRank(key string, values ...float64) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.TDigestRevRank(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTDigestTrimmedMean(key string, lowCutQuantile, highCutQuantile float64) *ExpectedFloat {
+	e := &ExpectedFloat{}
+	e.cmd = m.factory.TDigestTrimmedMean(m.ctx, key, lowCutQuantile, highCutQuantile)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSAdd(key string, timestamp interface{}, value float64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.TSAdd(m.ctx, key, timestamp, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSAddWithArgs(key string, timestamp interface{}, value float64, options *redis.TSOptions) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.TSAddWithArgs(m.ctx, key, timestamp, value, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSCreate(key string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TSCreate(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSCreateWithArgs(key string, options *redis.TSOptions) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TSCreateWithArgs(m.ctx, key, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSAlter(key string, options *redis.TSAlterOptions) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TSAlter(m.ctx, key, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSCreateRule(sourceKey string, destKey string, aggregator redis.Aggregator, bucketDuration int) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TSCreateRule(m.ctx, sourceKey, destKey, aggregator, bucketDuration)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSCreateRuleWithArgs(sourceKey string, destKey string, aggregator redis.Aggregator, bucketDuration int, options *redis.TSCreateRuleOptions) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TSCreateRuleWithArgs(m.ctx, sourceKey, destKey, aggregator, bucketDuration, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSIncrBy(key string, timestamp float64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.TSIncrBy(m.ctx, key, timestamp)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSIncrByWithArgs(key string, timestamp float64, options *redis.TSIncrDecrOptions) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.TSIncrByWithArgs(m.ctx, key, timestamp, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSDecrBy(key string, timestamp float64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.TSDecrBy(m.ctx, key, timestamp)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSDecrByWithArgs(key string, timestamp float64, options *redis.TSIncrDecrOptions) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.TSDecrByWithArgs(m.ctx, key, timestamp, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSDel(key string, fromTimestamp int, toTimestamp int) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.TSDel(m.ctx, key, fromTimestamp, toTimestamp)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSDeleteRule(sourceKey string, destKey string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.TSDeleteRule(m.ctx, sourceKey, destKey)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSGet(key string) *ExpectedTSTimestampValue {
+	e := &ExpectedTSTimestampValue{}
+	e.cmd = m.factory.TSGet(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSGetWithArgs(key string, options *redis.TSGetOptions) *ExpectedTSTimestampValue {
+	e := &ExpectedTSTimestampValue{}
+	e.cmd = m.factory.TSGetWithArgs(m.ctx, key, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSInfo(key string) *ExpectedMapStringInterface {
+	e := &ExpectedMapStringInterface{}
+	e.cmd = m.factory.TSInfo(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSInfoWithArgs(key string, options *redis.TSInfoOptions) *ExpectedMapStringInterface {
+	e := &ExpectedMapStringInterface{}
+	e.cmd = m.factory.TSInfoWithArgs(m.ctx, key, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSMAdd(ktvSlices [][]interface{}) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.TSMAdd(m.ctx, ktvSlices)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSQueryIndex(filterExpr []string) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.TSQueryIndex(m.ctx, filterExpr)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSRevRange(key string, fromTimestamp int, toTimestamp int) *ExpectedTSTimestampValueSlice {
+	e := &ExpectedTSTimestampValueSlice{}
+	e.cmd = m.factory.TSRevRange(m.ctx, key, fromTimestamp, toTimestamp)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSRevRangeWithArgs(key string, fromTimestamp int, toTimestamp int, options *redis.TSRevRangeOptions) *ExpectedTSTimestampValueSlice {
+	e := &ExpectedTSTimestampValueSlice{}
+	e.cmd = m.factory.TSRevRangeWithArgs(m.ctx, key, fromTimestamp, toTimestamp, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSRange(key string, fromTimestamp int, toTimestamp int) *ExpectedTSTimestampValueSlice {
+	e := &ExpectedTSTimestampValueSlice{}
+	e.cmd = m.factory.TSRange(m.ctx, key, fromTimestamp, toTimestamp)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSRangeWithArgs(key string, fromTimestamp int, toTimestamp int, options *redis.TSRangeOptions) *ExpectedTSTimestampValueSlice {
+	e := &ExpectedTSTimestampValueSlice{}
+	e.cmd = m.factory.TSRangeWithArgs(m.ctx, key, fromTimestamp, toTimestamp, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSMRange(fromTimestamp int, toTimestamp int, filterExpr []string) *ExpectedMapStringSliceInterface {
+	e := &ExpectedMapStringSliceInterface{}
+	e.cmd = m.factory.TSMRange(m.ctx, fromTimestamp, toTimestamp, filterExpr)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSMRangeWithArgs(fromTimestamp int, toTimestamp int, filterExpr []string, options *redis.TSMRangeOptions) *ExpectedMapStringSliceInterface {
+	e := &ExpectedMapStringSliceInterface{}
+	e.cmd = m.factory.TSMRangeWithArgs(m.ctx, fromTimestamp, toTimestamp, filterExpr, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSMRevRange(fromTimestamp int, toTimestamp int, filterExpr []string) *ExpectedMapStringSliceInterface {
+	e := &ExpectedMapStringSliceInterface{}
+	e.cmd = m.factory.TSMRevRange(m.ctx, fromTimestamp, toTimestamp, filterExpr)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSMRevRangeWithArgs(fromTimestamp int, toTimestamp int, filterExpr []string, options *redis.TSMRevRangeOptions) *ExpectedMapStringSliceInterface {
+	e := &ExpectedMapStringSliceInterface{}
+	e.cmd = m.factory.TSMRevRangeWithArgs(m.ctx, fromTimestamp, toTimestamp, filterExpr, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSMGet(filters []string) *ExpectedMapStringSliceInterface {
+	e := &ExpectedMapStringSliceInterface{}
+	e.cmd = m.factory.TSMGet(m.ctx, filters)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectTSMGetWithArgs(filters []string, options *redis.TSMGetOptions) *ExpectedMapStringSliceInterface {
+	e := &ExpectedMapStringSliceInterface{}
+	e.cmd = m.factory.TSMGetWithArgs(m.ctx, filters, options)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectGetBit(key string, offset int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.GetBit(m.ctx, key, offset)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectSetBit(key string, offset int64, value int) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.SetBit(m.ctx, key, offset, value)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitCount(key string, bitCount *redis.BitCount) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BitCount(m.ctx, key, bitCount)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitOpAnd(destKey string, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BitOpAnd(m.ctx, destKey, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitOpOr(destKey string, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BitOpOr(m.ctx, destKey, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitOpXor(destKey string, keys ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BitOpXor(m.ctx, destKey, keys...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitOpNot(destKey string, key string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BitOpNot(m.ctx, destKey, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitPos(key string, bit int64, pos ...int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BitPos(m.ctx, key, bit, pos...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitPosSpan(key string, bit int8, start, end int64, span string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.BitPosSpan(m.ctx, key, bit, start, end, span)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectBitField(key string, values ...interface{}) *ExpectedIntSlice {
+	e := &ExpectedIntSlice{}
+	e.cmd = m.factory.BitField(m.ctx, key, values...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXAdd(a *redis.XAddArgs) *ExpectedString {
+	e := &ExpectedString{}
+	e.cmd = m.factory.XAdd(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXDel(stream string, ids ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XDel(m.ctx, stream, ids...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXLen(stream string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XLen(m.ctx, stream)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXRange(stream, start, stop string) *ExpectedXMessageSlice {
+	e := &ExpectedXMessageSlice{}
+	e.cmd = m.factory.XRange(m.ctx, stream, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXRangeN(stream, start, stop string, count int64) *ExpectedXMessageSlice {
+	e := &ExpectedXMessageSlice{}
+	e.cmd = m.factory.XRangeN(m.ctx, stream, start, stop, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXRevRange(stream string, start, stop string) *ExpectedXMessageSlice {
+	e := &ExpectedXMessageSlice{}
+	e.cmd = m.factory.XRevRange(m.ctx, stream, start, stop)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXRevRangeN(stream string, start, stop string, count int64) *ExpectedXMessageSlice {
+	e := &ExpectedXMessageSlice{}
+	e.cmd = m.factory.XRevRangeN(m.ctx, stream, start, stop, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXRead(a *redis.XReadArgs) *ExpectedXStreamSlice {
+	e := &ExpectedXStreamSlice{}
+	e.cmd = m.factory.XRead(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXReadStreams(streams ...string) *ExpectedXStreamSlice {
+	e := &ExpectedXStreamSlice{}
+	e.cmd = m.factory.XReadStreams(m.ctx, streams...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXGroupCreate(stream, group, start string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.XGroupCreate(m.ctx, stream, group, start)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXGroupCreateMkStream(stream, group, start string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.XGroupCreateMkStream(m.ctx, stream, group, start)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXGroupSetID(stream, group, start string) *ExpectedStatus {
+	e := &ExpectedStatus{}
+	e.cmd = m.factory.XGroupSetID(m.ctx, stream, group, start)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXGroupDestroy(stream, group string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XGroupDestroy(m.ctx, stream, group)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXGroupCreateConsumer(stream, group, consumer string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XGroupCreateConsumer(m.ctx, stream, group, consumer)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXGroupDelConsumer(stream, group, consumer string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XGroupDelConsumer(m.ctx, stream, group, consumer)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXReadGroup(a *redis.XReadGroupArgs) *ExpectedXStreamSlice {
+	e := &ExpectedXStreamSlice{}
+	e.cmd = m.factory.XReadGroup(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXAck(stream, group string, ids ...string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XAck(m.ctx, stream, group, ids...)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXPending(stream, group string) *ExpectedXPending {
+	e := &ExpectedXPending{}
+	e.cmd = m.factory.XPending(m.ctx, stream, group)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXPendingExt(a *redis.XPendingExtArgs) *ExpectedXPendingExt {
+	e := &ExpectedXPendingExt{}
+	e.cmd = m.factory.XPendingExt(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXClaim(a *redis.XClaimArgs) *ExpectedXMessageSlice {
+	e := &ExpectedXMessageSlice{}
+	e.cmd = m.factory.XClaim(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXClaimJustID(a *redis.XClaimArgs) *ExpectedStringSlice {
+	e := &ExpectedStringSlice{}
+	e.cmd = m.factory.XClaimJustID(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXAutoClaim(a *redis.XAutoClaimArgs) *ExpectedXAutoClaim {
+	e := &ExpectedXAutoClaim{}
+	e.cmd = m.factory.XAutoClaim(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXAutoClaimJustID(a *redis.XAutoClaimArgs) *ExpectedXAutoClaimJustID {
+	e := &ExpectedXAutoClaimJustID{}
+	e.cmd = m.factory.XAutoClaimJustID(m.ctx, a)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXTrimMaxLen(key string, maxLen int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XTrimMaxLen(m.ctx, key, maxLen)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXTrimMaxLenApprox(key string, maxLen, limit int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XTrimMaxLenApprox(m.ctx, key, maxLen, limit)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXTrimMinID(key string, minID string) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XTrimMinID(m.ctx, key, minID)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXTrimMinIDApprox(key string, minID string, limit int64) *ExpectedInt {
+	e := &ExpectedInt{}
+	e.cmd = m.factory.XTrimMinIDApprox(m.ctx, key, minID, limit)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXInfoGroups(key string) *ExpectedXInfoGroups {
+	e := &ExpectedXInfoGroups{}
+	e.cmd = m.factory.XInfoGroups(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXInfoStream(key string) *ExpectedXInfoStream {
+	e := &ExpectedXInfoStream{}
+	e.cmd = m.factory.XInfoStream(m.ctx, key)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXInfoStreamFull(key string, count int) *ExpectedXInfoStreamFull {
+	e := &ExpectedXInfoStreamFull{}
+	e.cmd = m.factory.XInfoStreamFull(m.ctx, key, count)
+	m.pushExpect(e)
+	return e
+}
+
+func (m *mock) ExpectXInfoConsumers(key string, group string) *ExpectedXInfoConsumers {
+	e := &ExpectedXInfoConsumers{}
+	e.cmd = m.factory.XInfoConsumers(m.ctx, key, group)
+	m.pushExpect(e)
+	return e
+}
@@ -0,0 +1,169 @@
+package redismock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyspaceClasses are the classes of the keyspace events, by flag of notify-keyspace-events.
+var keyspaceClasses = map[string]byte{
+	"del": 'g', "expire": 'g', "rename_from": 'g', "rename_to": 'g', "move_from": 'g', "move_to": 'g',
+	"copy_to": 'g', "restore": 'g', "persist": 'g',
+
+	"set": '$', "setrange": '$', "incrby": '$', "incrbyfloat": '$', "append": '$',
+
+	"lpush": 'l', "rpush": 'l', "lpop": 'l', "rpop": 'l', "linsert": 'l', "lset": 'l', "lrem": 'l',
+	"ltrim": 'l',
+
+	"sadd": 's', "srem": 's', "spop": 's', "sinterstore": 's', "sunionstore": 's', "sdiffstore": 's',
+
+	"hset": 'h', "hincrby": 'h', "hincrbyfloat": 'h', "hdel": 'h',
+
+	"zadd": 'z', "zincr": 'z', "zrem": 'z', "zremrangebyscore": 'z', "zremrangebyrank": 'z',
+	"zremrangebylex": 'z', "zinterstore": 'z', "zunionstore": 'z', "zdiffstore": 'z',
+	"zpopmin": 'z', "zpopmax": 'z',
+
+	"xadd": 't', "xtrim": 't', "xdel": 't', "xgroup-create": 't', "xgroup-createconsumer": 't',
+	"xgroup-delconsumer": 't', "xgroup-destroy": 't', "xgroup-setid": 't', "xsetid": 't',
+
+	"expired": 'x', "evicted": 'e', "new": 'n', "keymiss": 'm',
+}
+
+// keyspaceNotifier publishes the keyspace and keyevent notifications of database 0
+// to the clients of the servers of a mock, as enabled by notify-keyspace-events.
+type keyspaceNotifier struct {
+	mu      sync.Mutex
+	flags   string
+	servers []*Server
+}
+
+// parseKeyspaceFlags returns the flags with A expanded, Redis accepts them in any order.
+func parseKeyspaceFlags(flags string) (string, error) {
+	var set []byte
+	for i := 0; i < len(flags); i++ {
+		c := flags[i]
+		switch {
+		case c == 'A':
+			set = append(set, "g$lshzxetd"...)
+		case strings.IndexByte("KEg$lshzxetdmn", c) >= 0:
+			set = append(set, c)
+		default:
+			return "", fmt.Errorf("redismock: invalid notify-keyspace-events flag %q", c)
+		}
+	}
+	return string(set), nil
+}
+
+func (n *keyspaceNotifier) setFlags(flags string) error {
+	set, err := parseKeyspaceFlags(flags)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.flags = set
+	n.mu.Unlock()
+	return nil
+}
+
+// getFlags returns the flags as CONFIG GET does, the classes of A are written as A.
+func (n *keyspaceNotifier) getFlags() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var out []byte
+	all := true
+	for _, c := range []byte("g$lshzxetd") {
+		all = all && strings.IndexByte(n.flags, c) >= 0
+	}
+	if all {
+		out = append(out, 'A')
+	}
+	for _, c := range []byte("g$lshzxetdmnKE") {
+		if all && strings.IndexByte("g$lshzxetd", c) >= 0 {
+			continue
+		}
+		if strings.IndexByte(n.flags, c) >= 0 {
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
+
+func (n *keyspaceNotifier) addServer(s *Server) {
+	n.mu.Lock()
+	n.servers = append(n.servers, s)
+	n.mu.Unlock()
+}
+
+func (n *keyspaceNotifier) removeServer(s *Server) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, srv := range n.servers {
+		if srv == s {
+			n.servers = append(n.servers[:i], n.servers[i+1:]...)
+			return
+		}
+	}
+}
+
+// emit publishes an event of a key if its class is enabled, an unknown event is of the generic class.
+func (n *keyspaceNotifier) emit(event, key string) {
+	class, ok := keyspaceClasses[event]
+	if !ok {
+		class = 'g'
+	}
+
+	n.mu.Lock()
+	flags := n.flags
+	servers := append([]*Server(nil), n.servers...)
+	n.mu.Unlock()
+
+	if strings.IndexByte(flags, class) < 0 {
+		return
+	}
+	for _, s := range servers {
+		if strings.IndexByte(flags, 'K') >= 0 {
+			s.publish("__keyspace@0__:"+key, event)
+		}
+		if strings.IndexByte(flags, 'E') >= 0 {
+			s.publish("__keyevent@0__:"+event, key)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// SetNotifyKeyspaceEvents sets notify-keyspace-events, the classes of the events published
+// to the clients of a Server, in the format of Redis such as "KEA" or "Ex".
+func (m *mock) SetNotifyKeyspaceEvents(flags string) error {
+	if m.parent != nil {
+		return m.parent.SetNotifyKeyspaceEvents(flags)
+	}
+	return m.notifier.setFlags(flags)
+}
+
+// NotifyKeyspaceEvent publishes the notifications of an event of a key, such as "expired" or "set",
+// to the clients of the Servers of the mock subscribed to them.
+func (m *mock) NotifyKeyspaceEvent(event, key string) {
+	if m.parent != nil {
+		m.parent.NotifyKeyspaceEvent(event, key)
+		return
+	}
+	m.notifier.emit(event, key)
+}
+
+// applyConfig keeps notify-keyspace-events set by an expected CONFIG SET.
+func (m *mock) applyConfig(cmd redis.Cmder) {
+	args := formatArgs(cmd.Args())
+	if protocolCommand(args) != "config set" {
+		return
+	}
+	for i := 2; i+1 < len(args); i += 2 {
+		if strings.EqualFold(args[i], "notify-keyspace-events") {
+			_ = m.notifier.setFlags(args[i+1])
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package redismock
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Notify", func() {
+	var (
+		clientMock ClientMock
+		srv        *Server
+		sub        *redis.PubSub
+	)
+
+	subscribe := func(patterns ...string) *redis.PubSub {
+		sub := redis.NewClient(srv.Options()).PSubscribe(ctx, patterns...)
+		for range patterns {
+			_, err := sub.Receive(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		return sub
+	}
+
+	receive := func() []string {
+		msg, err := sub.ReceiveTimeout(ctx, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msg).To(BeAssignableToTypeOf(&redis.Message{}))
+		return []string{msg.(*redis.Message).Channel, msg.(*redis.Message).Payload}
+	}
+
+	nothing := func() {
+		_, err := sub.ReceiveTimeout(ctx, 50*time.Millisecond)
+		Expect(err).To(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		_, clientMock = NewClientMock()
+		srv, err = NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+		sub = subscribe("__keyspace@0__:*", "__keyevent@0__:*")
+	})
+
+	AfterEach(func() {
+		Expect(sub.Close()).NotTo(HaveOccurred())
+		Expect(srv.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("trigger", func() {
+		Expect(clientMock.SetNotifyKeyspaceEvents("KEA")).NotTo(HaveOccurred())
+
+		clientMock.NotifyKeyspaceEvent("expired", "session:1")
+		Expect(receive()).To(Equal([]string{"__keyspace@0__:session:1", "expired"}))
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:expired", "session:1"}))
+	})
+
+	It("flags", func() {
+		// disabled by default
+		clientMock.NotifyKeyspaceEvent("del", "key")
+		nothing()
+
+		Expect(clientMock.SetNotifyKeyspaceEvents("Ex")).NotTo(HaveOccurred())
+		clientMock.NotifyKeyspaceEvent("del", "key")
+		clientMock.NotifyKeyspaceEvent("expired", "key")
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:expired", "key"}))
+		nothing()
+
+		Expect(clientMock.SetNotifyKeyspaceEvents("Eq")).To(HaveOccurred())
+	})
+
+	It("config set", func() {
+		client := redis.NewClient(srv.Options())
+		defer client.Close()
+
+		clientMock.ExpectConfigSet("notify-keyspace-events", "K$").SetVal("OK")
+		Expect(client.ConfigSet(ctx, "notify-keyspace-events", "K$").Err()).NotTo(HaveOccurred())
+
+		clientMock.NotifyKeyspaceEvent("set", "key")
+		clientMock.NotifyKeyspaceEvent("lpush", "list")
+		Expect(receive()).To(Equal([]string{"__keyspace@0__:key", "set"}))
+		nothing()
+	})
+
+	It("fake", func() {
+		Expect(sub.Close()).NotTo(HaveOccurred())
+		Expect(srv.Close()).NotTo(HaveOccurred())
+
+		var err error
+		_, fakeMock := NewClientFakeMock()
+		srv, err = NewServer(fakeMock)
+		Expect(err).NotTo(HaveOccurred())
+		sub = subscribe("__keyevent@0__:*")
+
+		client := redis.NewClient(srv.Options())
+		defer client.Close()
+
+		Expect(client.ConfigSet(ctx, "notify-keyspace-events", "Eg$lx").Err()).NotTo(HaveOccurred())
+		Expect(client.ConfigGet(ctx, "notify-keyspace-events").Result()).
+			To(Equal(map[string]string{"notify-keyspace-events": "g$lxE"}))
+
+		Expect(client.Set(ctx, "key", "1", time.Minute).Err()).NotTo(HaveOccurred())
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:set", "key"}))
+
+		Expect(client.RPush(ctx, "list", "a").Err()).NotTo(HaveOccurred())
+		Expect(client.LPop(ctx, "list").Err()).NotTo(HaveOccurred())
+		Expect(client.LPop(ctx, "list").Err()).To(Equal(redis.Nil))
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:rpush", "list"}))
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:lpop", "list"}))
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:del", "list"}))
+
+		Expect(client.Rename(ctx, "key", "other").Err()).NotTo(HaveOccurred())
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:rename_from", "key"}))
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:rename_to", "other"}))
+
+		fakeMock.Clock().Advance(time.Hour)
+		Expect(receive()).To(Equal([]string{"__keyevent@0__:expired", "other"}))
+		nothing()
+
+		Expect(client.ConfigSet(ctx, "notify-keyspace-events", "Eq").Err()).To(HaveOccurred())
+		Expect(fakeMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,91 @@
+package redismock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SetProtocol selects the semantics of RESP2 or RESP3 for the replies of the expectations,
+// 0 restores the default of returning the values as they are set.
+//
+// The values are encoded as Redis would reply with the protocol and decoded by go-redis,
+// ExpectDo of HGETALL or CONFIG GET returns a map with RESP3 and a flat array with RESP2,
+// ZSCORE is read from a double or a bulk string, and a value the command cannot reply is an error.
+func (m *mock) SetProtocol(protocol int) {
+	if m.parent != nil {
+		m.parent.SetProtocol(protocol)
+		return
+	}
+	if protocol != 0 && protocol != 2 && protocol != 3 {
+		panic(fmt.Sprintf("redismock: unsupported protocol %d", protocol))
+	}
+	m.protocol = protocol
+	if m.fake != nil {
+		m.fake.setProtocol(protocol)
+	}
+}
+
+// inflowProtocol sets the value of an expectation into cmd through the reply of m.protocol.
+// The values of the Cmd types without an encoding, whose shape is the same in RESP2 and RESP3,
+// are set as they are.
+func (m *mock) inflowProtocol(e expectation, cmd redis.Cmder) error {
+	reply, err := encodeExpectation(e, cmd.Name())
+	if errors.Is(err, errNoEncoding) {
+		return e.inflow(cmd)
+	}
+	if err != nil {
+		return err
+	}
+	reply = protocolReply(m.protocol, formatArgs(cmd.Args()), reply)
+
+	var buf bytes.Buffer
+	w := newRESPWriter(&buf, m.protocol)
+	w.write(reply)
+	if err = w.flush(); err != nil {
+		return err
+	}
+	if err = processRESP(cmd, buf.Bytes()); err != nil {
+		return err
+	}
+	return cmd.Err()
+}
+
+// protocolReply converts the replies whose type is not known from the value,
+// such as the flat array set by ExpectDo, to the type of the command in RESP3.
+func protocolReply(protocol int, args []string, reply interface{}) interface{} {
+	vals, ok := reply.([]interface{})
+	if protocol != 3 || !ok {
+		return reply
+	}
+
+	switch name := protocolCommand(args); name {
+	case "hgetall", "config get", "hello":
+		if len(vals)%2 == 0 {
+			return respMap(vals)
+		}
+	case "subscribe", "unsubscribe", "psubscribe", "punsubscribe", "ssubscribe", "sunsubscribe":
+		return respPush(vals)
+	case "smembers", "sinter", "sunion", "sdiff":
+		return respSet(vals)
+	}
+	return reply
+}
+
+// protocolCommand returns the name of a command, with the subcommand of the container commands.
+func protocolCommand(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	name := strings.ToLower(args[0])
+	switch name {
+	case "config", "client":
+		if len(args) > 1 {
+			name += " " + strings.ToLower(args[1])
+		}
+	}
+	return name
+}
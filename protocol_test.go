@@ -0,0 +1,130 @@
+package redismock
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Protocol", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientMock()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("resp2", func() {
+		clientMock.SetProtocol(2)
+
+		clientMock.ExpectDo("hgetall", "hash").SetVal(map[interface{}]interface{}{"f1": "v1", "f2": "v2"})
+		clientMock.ExpectHGetAll("hash").SetVal(map[string]string{"f1": "v1"})
+		clientMock.ExpectZScore("z", "a").SetVal(1.5)
+		clientMock.ExpectZRangeWithScores("z", 0, -1).SetVal([]redis.Z{{Score: 1.5, Member: "a"}})
+		clientMock.ExpectSIsMember("set", "a").SetVal(true)
+
+		Expect(client.Do(ctx, "hgetall", "hash").Val()).To(Equal([]interface{}{"f1", "v1", "f2", "v2"}))
+		Expect(client.Do(ctx, "hgetall", "hash").Val()).To(Equal([]interface{}{"f1", "v1"}))
+		Expect(client.Do(ctx, "zscore", "z", "a").Val()).To(Equal("1.5"))
+		Expect(client.Do(ctx, "zrange", "z", int64(0), int64(-1), "withscores").Val()).
+			To(Equal([]interface{}{"a", "1.5"}))
+		Expect(client.Do(ctx, "sismember", "set", "a").Val()).To(Equal(int64(1)))
+	})
+
+	It("resp3", func() {
+		clientMock.SetProtocol(3)
+
+		clientMock.ExpectDo("hgetall", "hash").SetVal([]interface{}{"f1", "v1"})
+		clientMock.ExpectHGetAll("hash").SetVal(map[string]string{"f1": "v1"})
+		clientMock.ExpectDo("config", "get", "maxmemory").SetVal([]interface{}{"maxmemory", "0"})
+		clientMock.ExpectZScore("z", "a").SetVal(1.5)
+		clientMock.ExpectZRangeWithScores("z", 0, -1).SetVal([]redis.Z{{Score: 1.5, Member: "a"}})
+		clientMock.ExpectDo("subscribe", "ch").SetVal([]interface{}{"subscribe", "ch", int64(1)})
+
+		hash := map[interface{}]interface{}{"f1": "v1"}
+		Expect(client.Do(ctx, "hgetall", "hash").Val()).To(Equal(hash))
+		Expect(client.Do(ctx, "hgetall", "hash").Val()).To(Equal(hash))
+		Expect(client.Do(ctx, "config", "get", "maxmemory").Val()).
+			To(Equal(map[interface{}]interface{}{"maxmemory": "0"}))
+		Expect(client.Do(ctx, "zscore", "z", "a").Val()).To(Equal(1.5))
+		Expect(client.Do(ctx, "zrange", "z", int64(0), int64(-1), "withscores").Val()).
+			To(Equal([]interface{}{[]interface{}{"a", 1.5}}))
+		Expect(client.Do(ctx, "subscribe", "ch").Val()).To(Equal([]interface{}{"subscribe", "ch", int64(1)}))
+	})
+
+	for _, protocol := range []int{2, 3} {
+		protocol := protocol
+
+		It(fmt.Sprintf("typed commands resp%d", protocol), func() {
+			clientMock.SetProtocol(protocol)
+
+			clientMock.ExpectHGetAll("hash").SetVal(map[string]string{"f1": "v1", "f2": "v2"})
+			clientMock.ExpectConfigGet("maxmemory").SetVal(map[string]string{"maxmemory": "0"})
+			clientMock.ExpectZScore("z", "a").SetVal(1.5)
+			clientMock.ExpectZRangeWithScores("z", 0, -1).SetVal([]redis.Z{{Score: 1.5, Member: "a"}})
+			clientMock.ExpectSMembers("set").SetVal([]string{"a"})
+			clientMock.ExpectGet("none").RedisNil()
+
+			Expect(client.HGetAll(ctx, "hash").Val()).To(Equal(map[string]string{"f1": "v1", "f2": "v2"}))
+			Expect(client.ConfigGet(ctx, "maxmemory").Val()).To(Equal(map[string]string{"maxmemory": "0"}))
+			Expect(client.ZScore(ctx, "z", "a").Val()).To(Equal(1.5))
+			Expect(client.ZRangeWithScores(ctx, "z", 0, -1).Val()).To(Equal([]redis.Z{{Score: 1.5, Member: "a"}}))
+			Expect(client.SMembers(ctx, "set").Val()).To(Equal([]string{"a"}))
+			Expect(client.Get(ctx, "none").Err()).To(Equal(redis.Nil))
+
+			// the values of the commands without an encoding are set as they are
+			pending := &redis.XPending{Count: 1, Lower: "1-0", Higher: "1-0", Consumers: map[string]int64{"c": 1}}
+			clientMock.ExpectXPending("stream", "group").SetVal(pending)
+			clientMock.ExpectLMPop("left", 1, "list").SetVal("list", []string{"a"})
+			Expect(client.XPending(ctx, "stream", "group").Val()).To(Equal(pending))
+			key, vals, err := client.LMPop(ctx, "left", 1, "list").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("list"))
+			Expect(vals).To(Equal([]string{"a"}))
+		})
+
+		It(fmt.Sprintf("fake resp%d", protocol), func() {
+			fake, fakeMock := NewClientFakeMock()
+			defer fake.Close()
+			fakeMock.SetProtocol(protocol)
+
+			Expect(fake.HSet(ctx, "hash", "f", "v").Val()).To(Equal(int64(1)))
+			Expect(fake.ZAdd(ctx, "z", redis.Z{Score: 1.5, Member: "a"}).Val()).To(Equal(int64(1)))
+			Expect(fake.ZRangeWithScores(ctx, "z", 0, -1).Val()).To(Equal([]redis.Z{{Score: 1.5, Member: "a"}}))
+
+			hash := fake.Do(ctx, "hgetall", "hash").Val()
+			score := fake.Do(ctx, "zscore", "z", "a").Val()
+			if protocol == 3 {
+				Expect(hash).To(Equal(map[interface{}]interface{}{"f": "v"}))
+				Expect(score).To(Equal(1.5))
+			} else {
+				Expect(hash).To(Equal([]interface{}{"f", "v"}))
+				Expect(score).To(Equal("1.5"))
+			}
+		})
+	}
+
+	It("invalid reply", func() {
+		clientMock.SetProtocol(3)
+		clientMock.ExpectDo("incr", "key").SetVal("abc")
+		Expect(client.Incr(ctx, "key").Err()).To(HaveOccurred())
+	})
+
+	It("unsupported protocol", func() {
+		Expect(func() { clientMock.SetProtocol(4) }).To(Panic())
+	})
+
+	It("default", func() {
+		clientMock.ExpectDo("hgetall", "hash").SetVal([]interface{}{"f1", "v1"})
+		Expect(client.Do(ctx, "hgetall", "hash").Val()).To(Equal([]interface{}{"f1", "v1"}))
+	})
+})
@@ -0,0 +1,556 @@
+package redismock
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ParseRESP decodes the first reply of raw RESP2/RESP3 bytes into the value returned by redis.Cmd,
+// the shape ExpectedCmd.SetVal expects, for example:
+//
+//	"%1\r\n+key\r\n:1\r\n" => map[interface{}]interface{}{"key": int64(1)}
+//
+// The reply is decoded by go-redis itself, an error reply is returned as the error go-redis
+// would return and a null reply as redis.Nil.
+func ParseRESP(raw []byte) (interface{}, error) {
+	val, replyErr, err := decodeRESP(raw)
+	if err != nil {
+		return nil, err
+	}
+	return val, replyErr
+}
+
+// decodeRESP returns the decoded reply and the error of the reply,
+// err is not nil if raw is not a valid reply.
+func decodeRESP(raw []byte) (val interface{}, replyErr, err error) {
+	cmd := redis.NewCmd(context.Background(), "resp")
+	if err = processRESP(cmd, raw); err != nil {
+		return nil, nil, err
+	}
+	val, replyErr = cmd.Result()
+	return val, replyErr, nil
+}
+
+// processRESP reads raw as the reply of cmd, the way go-redis reads it from a connection,
+// err is not nil if raw is not a valid reply.
+func processRESP(cmd redis.Cmder, raw []byte) error {
+	conn := newReplyConn(raw)
+	client := redis.NewClient(&redis.Options{
+		Dialer: func(_ context.Context, _, _ string) (net.Conn, error) {
+			return conn, nil
+		},
+		Protocol:         2,
+		DisableIndentity: true,
+		MaxRetries:       -1,
+		PoolSize:         1,
+	})
+	defer client.Close()
+
+	replyErr := client.Process(context.Background(), cmd)
+	if conn.malformed() {
+		return fmt.Errorf("redismock: malformed RESP reply %q: %v", raw, replyErr)
+	}
+	return nil
+}
+
+// ParseRESPText is ParseRESP for a readable description of the reply, one RESP frame per line
+// without the lengths of strings, for example:
+//
+//	%2
+//	  +name
+//	  $redis
+//	  +modules
+//	  *0
+//
+// The indentation of the lines is ignored, and bulk strings ($), blob errors (!) and verbatim strings (=) are
+// written inline up to the end of the line, trailing spaces included. The "txt:" format of a verbatim string
+// may be omitted. "$-1", "*-1" and "_" are null replies.
+func ParseRESPText(text string) (interface{}, error) {
+	raw, err := respFromText(text)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRESP(raw)
+}
+
+// setRESP sets the decoded reply into the expectation, it panics if raw is not a valid reply.
+func setRESP(e *ExpectedCmd, raw []byte) {
+	val, replyErr, err := decodeRESP(raw)
+	if err != nil {
+		panic(err)
+	}
+	switch {
+	case replyErr == redis.Nil:
+		e.RedisNil()
+	case replyErr != nil:
+		e.SetErr(replyErr)
+	default:
+		e.SetVal(val)
+	}
+}
+
+func respFromText(text string) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, line := range strings.Split(text, "\n") {
+		// only the indentation is stripped, the spaces of a string are kept
+		line = strings.TrimLeft(strings.TrimSuffix(line, "\r"), " \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		typ, payload := line[0], line[1:]
+		switch typ {
+		case '$', '!', '=', '+', '-':
+		default:
+			line = strings.TrimRight(line, " \t")
+			payload = line[1:]
+		}
+		switch typ {
+		case '$', '!':
+			if typ == '$' && payload == "-1" {
+				buf.WriteString("$-1\r\n")
+				continue
+			}
+			fmt.Fprintf(&buf, "%c%d\r\n%s\r\n", typ, len(payload), payload)
+		case '=':
+			if len(payload) < 4 || payload[3] != ':' {
+				payload = "txt:" + payload
+			}
+			fmt.Fprintf(&buf, "=%d\r\n%s\r\n", len(payload), payload)
+		case '*', '%', '~', '>', '|':
+			if _, err := strconv.Atoi(payload); err != nil {
+				return nil, fmt.Errorf("redismock: line %d: invalid aggregate length %q", i+1, line)
+			}
+			buf.WriteString(line + "\r\n")
+		case '+', '-', ':', '_', ',', '#', '(':
+			buf.WriteString(line + "\r\n")
+		default:
+			return nil, fmt.Errorf("redismock: line %d: unknown RESP type %q", i+1, typ)
+		}
+	}
+	if buf.Len() == 0 {
+		return nil, errors.New("redismock: empty RESP reply")
+	}
+	return buf.Bytes(), nil
+}
+
+//------------------------------------------------------------------
+
+// replyConn is a connection answering the first command with a raw reply,
+// and the connection handshake as a server without HELLO.
+type replyConn struct {
+	reply []byte
+
+	mu      sync.Mutex
+	in      bytes.Buffer
+	out     bytes.Buffer
+	replied bool
+	eof     bool
+}
+
+func newReplyConn(reply []byte) *replyConn {
+	return &replyConn{reply: reply}
+}
+
+func (c *replyConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.in.Write(b)
+	for {
+		rd := bufio.NewReader(bytes.NewReader(c.in.Bytes()))
+		args, err := readCommand(rd)
+		if err != nil {
+			// wait for the rest of the command
+			return len(b), nil
+		}
+		c.in.Next(c.in.Len() - rd.Buffered())
+
+		switch {
+		case strings.EqualFold(args[0], "hello"):
+			c.out.WriteString("-ERR unknown command 'HELLO'\r\n")
+		case !c.replied:
+			c.replied = true
+			c.out.Write(c.reply)
+		default:
+			c.out.WriteString("-ERR connection already replied\r\n")
+		}
+	}
+}
+
+func (c *replyConn) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.out.Len() == 0 {
+		c.eof = true
+		return 0, io.EOF
+	}
+	return c.out.Read(b)
+}
+
+// malformed reports whether the reply was too short for the reader.
+func (c *replyConn) malformed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.eof
+}
+
+func (c *replyConn) Close() error                       { return nil }
+func (c *replyConn) LocalAddr() net.Addr                { return respAddr{} }
+func (c *replyConn) RemoteAddr() net.Addr               { return respAddr{} }
+func (c *replyConn) SetDeadline(_ time.Time) error      { return nil }
+func (c *replyConn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *replyConn) SetWriteDeadline(_ time.Time) error { return nil }
+
+type respAddr struct{}
+
+func (respAddr) Network() string { return "redismock" }
+func (respAddr) String() string  { return "redismock" }
+
+// readCommand reads a command sent by a client, an array of bulk strings.
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	line, err := readLine(rd)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		// inline command
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			return nil, errors.New("redismock: empty command")
+		}
+		return args, nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("redismock: invalid multibulk length %q", line)
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err = readLine(rd)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, fmt.Errorf("redismock: expected bulk string, got %q", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("redismock: invalid bulk length %q", line)
+		}
+		b := make([]byte, size+2)
+		if _, err = io.ReadFull(rd, b); err != nil {
+			return nil, err
+		}
+		args[i] = string(b[:size])
+	}
+	return args, nil
+}
+
+func readLine(rd *bufio.Reader) (string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+//------------------------------------------------------------------
+
+// Reply types of a server, the other values are written as:
+//
+//	nil => null, string => bulk string, int/int64 => integer, float64 => double,
+//	bool => boolean, []string and []interface{} => array, error => error.
+//
+// respPairs is the reply of the commands WITHSCORES, such as ZRANGE.
+type (
+	respStatus string
+	respError  string
+	respMap    []interface{} // key and value pairs, flat array in RESP2
+	respSet    []interface{}
+	respPush   []interface{}
+	respPairs  []interface{} // flat array in RESP2, array of pairs in RESP3
+	respNilArr struct{}
+)
+
+func (e respError) Error() string { return string(e) }
+
+// respWriter encodes replies for the protocol negotiated by the client.
+type respWriter struct {
+	wr    *bufio.Writer
+	proto int
+}
+
+func newRESPWriter(wr io.Writer, proto int) *respWriter {
+	return &respWriter{wr: bufio.NewWriter(wr), proto: proto}
+}
+
+func (w *respWriter) flush() error {
+	return w.wr.Flush()
+}
+
+func (w *respWriter) write(v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		if w.proto == 3 {
+			w.wr.WriteString("_\r\n")
+		} else {
+			w.wr.WriteString("$-1\r\n")
+		}
+	case respNilArr:
+		if w.proto == 3 {
+			w.wr.WriteString("_\r\n")
+		} else {
+			w.wr.WriteString("*-1\r\n")
+		}
+	case respStatus:
+		w.line('+', string(v))
+	case respError:
+		w.line('-', string(v))
+	case error:
+		w.line('-', v.Error())
+	case string:
+		w.bulk(v)
+	case int:
+		w.line(':', strconv.Itoa(v))
+	case int64:
+		w.line(':', strconv.FormatInt(v, 10))
+	case float64:
+		if w.proto == 3 {
+			w.line(',', formatFloat(v))
+		} else {
+			w.bulk(formatFloat(v))
+		}
+	case bool:
+		switch {
+		case w.proto == 3 && v:
+			w.wr.WriteString("#t\r\n")
+		case w.proto == 3:
+			w.wr.WriteString("#f\r\n")
+		case v:
+			w.wr.WriteString(":1\r\n")
+		default:
+			w.wr.WriteString(":0\r\n")
+		}
+	case []string:
+		w.line('*', strconv.Itoa(len(v)))
+		for _, s := range v {
+			w.bulk(s)
+		}
+	case []interface{}:
+		w.aggregate('*', v)
+	case respSet:
+		if w.proto == 3 {
+			w.aggregate('~', v)
+		} else {
+			w.aggregate('*', v)
+		}
+	case respPush:
+		if w.proto == 3 {
+			w.aggregate('>', v)
+		} else {
+			w.aggregate('*', v)
+		}
+	case respPairs:
+		if w.proto != 3 {
+			w.aggregate('*', v)
+			break
+		}
+		w.line('*', strconv.Itoa(len(v)/2))
+		for i := 0; i+1 < len(v); i += 2 {
+			w.aggregate('*', v[i:i+2])
+		}
+	case respMap:
+		if w.proto == 3 {
+			w.line('%', strconv.Itoa(len(v)/2))
+			for _, e := range v {
+				w.write(e)
+			}
+		} else {
+			w.aggregate('*', v)
+		}
+	default:
+		w.bulk(fmt.Sprint(v))
+	}
+}
+
+func (w *respWriter) aggregate(typ byte, vals []interface{}) {
+	w.line(typ, strconv.Itoa(len(vals)))
+	for _, v := range vals {
+		w.write(v)
+	}
+}
+
+func (w *respWriter) line(typ byte, s string) {
+	w.wr.WriteByte(typ)
+	w.wr.WriteString(s)
+	w.wr.WriteString("\r\n")
+}
+
+func (w *respWriter) bulk(s string) {
+	w.line('$', strconv.Itoa(len(s)))
+	w.wr.WriteString(s)
+	w.wr.WriteString("\r\n")
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+//------------------------------------------------------------------
+
+// respHandler executes the command of a client connection and returns the reply.
+type respHandler func(c *respConn, args []string) interface{}
+
+// respConn is the server side of a client connection.
+type respConn struct {
+	net.Conn
+
+	id int64
+	rd *bufio.Reader
+
+	// wmu guards the writer, the messages pushed to a client are written by other goroutines
+	wmu sync.Mutex
+	wr  *respWriter
+
+	// transaction started by MULTI, dirty if a command failed to be queued
+	multi  bool
+	dirty  bool
+	queued [][]string
+	tx     bool // the commands from MULTI to EXEC or DISCARD, recorded in the history
+
+	// asking is set by ASKING, the node of a Cluster accepts the next command of a slot it imports,
+	// readonly by READONLY, the replica of a Cluster serves the reads of the slots of its master
+	asking   bool
+	readonly bool
+
+	// monitor stops the MONITOR stream of the client
+	monitor func()
+
+	// ctx is done when the connection is closed, the blocked commands return
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// channels and patterns subscribed by the client, the connection only receives messages then in RESP2
+	channels map[string]struct{}
+	patterns map[string]struct{}
+
+	// tracking is set by CLIENT TRACKING ON, caching by CLIENT CACHING for the next command
+	tracking *ExpectedTracking
+	caching  string
+}
+
+// respReplies are several replies to a command, such as SUBSCRIBE of several channels.
+type respReplies []interface{}
+
+var respClientID int64
+
+// serveRESP serves the commands of a connection until it is closed, HELLO is answered here.
+func serveRESP(conn net.Conn, handle respHandler) {
+	newRESPConn(conn).serve(handle)
+}
+
+func newRESPConn(conn net.Conn) *respConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &respConn{
+		Conn:   conn,
+		id:     atomic.AddInt64(&respClientID, 1),
+		rd:     bufio.NewReader(conn),
+		wr:     newRESPWriter(conn, 2),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (c *respConn) Close() error {
+	c.cancel()
+	return c.Conn.Close()
+}
+
+func (c *respConn) serve(handle respHandler) {
+	defer c.Close()
+
+	for {
+		args, err := readCommand(c.rd)
+		if err != nil {
+			return
+		}
+
+		var reply interface{}
+		if strings.EqualFold(args[0], "hello") {
+			reply = c.hello(args[1:])
+		} else {
+			reply = handle(c, args)
+		}
+		if err = c.write(reply); err != nil {
+			return
+		}
+	}
+}
+
+// write sends a reply, or a message pushed to the client.
+func (c *respConn) write(reply interface{}) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if replies, ok := reply.(respReplies); ok {
+		for _, r := range replies {
+			c.wr.write(r)
+		}
+	} else {
+		c.wr.write(reply)
+	}
+	return c.wr.flush()
+}
+
+// protocol returns the protocol negotiated by HELLO.
+func (c *respConn) protocol() int {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	return c.wr.proto
+}
+
+func (c *respConn) hello(args []string) interface{} {
+	if len(args) > 0 {
+		proto, err := strconv.Atoi(args[0])
+		if err != nil {
+			return respError("ERR Protocol version is not an integer or out of range")
+		}
+		if proto != 2 && proto != 3 {
+			return respError("NOPROTO unsupported protocol version")
+		}
+		c.wmu.Lock()
+		c.wr.proto = proto
+		c.wmu.Unlock()
+	}
+	return respMap{
+		"server", "redis",
+		"version", "7.2.0",
+		"proto", int64(c.protocol()),
+		"id", c.id,
+		"mode", "standalone",
+		"role", "master",
+		"modules", []interface{}{},
+	}
+}
@@ -0,0 +1,122 @@
+package redismock
+
+import (
+	"math"
+	"math/big"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("RESP", func() {
+
+	Describe("parse", func() {
+
+		It("resp2", func() {
+			val, err := ParseRESP([]byte("*3\r\n$5\r\nhello\r\n:10\r\n*2\r\n+OK\r\n$-1\r\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]interface{}{"hello", int64(10), []interface{}{"OK", nil}}))
+
+			val, err = ParseRESP([]byte("$-1\r\n"))
+			Expect(err).To(Equal(redis.Nil))
+			Expect(val).To(BeNil())
+		})
+
+		It("resp3", func() {
+			val, err := ParseRESP([]byte("%2\r\n+key\r\n,1.5\r\n+set\r\n~2\r\n#t\r\n(12345678901234567890\r\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			big, _ := new(big.Int).SetString("12345678901234567890", 10)
+			Expect(val).To(Equal(map[interface{}]interface{}{
+				"key": 1.5,
+				"set": []interface{}{true, big},
+			}))
+
+			val, err = ParseRESP([]byte("=15\r\ntxt:Some string\r\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("Some string"))
+
+			val, err = ParseRESP([]byte(",inf\r\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal(math.Inf(1)))
+
+			_, err = ParseRESP([]byte("_\r\n"))
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("error reply", func() {
+			_, err := ParseRESP([]byte("-MOVED 3999 127.0.0.1:6381\r\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("MOVED 3999 127.0.0.1:6381"))
+			Expect(redis.HasErrorPrefix(err, "MOVED")).To(BeTrue())
+
+			_, err = ParseRESP([]byte("!21\r\nSYNTAX invalid syntax\r\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("SYNTAX invalid syntax"))
+		})
+
+		It("malformed", func() {
+			_, err := ParseRESP([]byte("*2\r\n:1\r\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("malformed"))
+		})
+
+		It("text", func() {
+			val, err := ParseRESPText(`
+				%2
+				  +name
+				  $redis
+				  +modules
+				  *2
+				    =search
+				    $-1
+			`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal(map[interface{}]interface{}{
+				"name":    "redis",
+				"modules": []interface{}{"search", nil},
+			}))
+
+			// the spaces of a string are kept, not the indentation
+			val, err = ParseRESPText("\t*2 \r\n\t  $  padded  \n\t  :1 \n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]interface{}{"  padded  ", int64(1)}))
+
+			_, err = ParseRESPText("?1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("expected cmd", func() {
+		var (
+			client     *redis.Client
+			clientMock ClientMock
+		)
+
+		BeforeEach(func() {
+			client, clientMock = NewClientMock()
+		})
+
+		AfterEach(func() {
+			Expect(client.Close()).NotTo(HaveOccurred())
+			Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+		})
+
+		It("set resp", func() {
+			clientMock.ExpectDo("hgetall", "key").SetRESP([]byte("%1\r\n$5\r\nfield\r\n$5\r\nvalue\r\n"))
+			clientMock.ExpectDo("get", "key").SetRESP([]byte("$-1\r\n"))
+			clientMock.ExpectDo("get", "key").SetRESPText("-WRONGTYPE Operation against a key holding the wrong kind of value")
+
+			val, err := client.Do(ctx, "hgetall", "key").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal(map[interface{}]interface{}{"field": "value"}))
+
+			_, err = client.Do(ctx, "get", "key").Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			_, err = client.Do(ctx, "get", "key").Result()
+			Expect(redis.HasErrorPrefix(err, "WRONGTYPE")).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package redismock
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Routing", func() {
+	var (
+		cluster     *Cluster
+		clusterMock ClusterClientMock
+	)
+
+	BeforeEach(func() {
+		var err error
+		cluster, err = NewCluster(
+			ClusterNodeSpec{Name: "master-1", Slots: []SlotRange{{0, 8191}}},
+			ClusterNodeSpec{Name: "master-2", Slots: []SlotRange{{8192, 16383}}},
+			ClusterNodeSpec{Name: "replica-1", ReplicaOf: "master-1"},
+			ClusterNodeSpec{Name: "replica-2", ReplicaOf: "master-2"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		clusterMock = cluster.Mock()
+	})
+
+	AfterEach(func() {
+		Expect(cluster.Close()).NotTo(HaveOccurred())
+		Expect(clusterMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	// last returns the node of the last command of the history.
+	last := func() string {
+		history := clusterMock.History()
+		return history[len(history)-1].Node
+	}
+
+	It("readonly", func() {
+		opt := cluster.Options()
+		opt.ReadOnly = true
+		client := redis.NewClusterClient(opt)
+		defer client.Close()
+
+		clusterMock.Node("replica-1").ExpectGet("user").SetVal("replica")
+		clusterMock.Node("master-1").ExpectSet("user", "alice", 0).SetVal("OK")
+		clusterMock.Node(cluster.Addr("replica-2")).ExpectGet("key").SetVal("replica")
+
+		Expect(client.Get(ctx, "user").Val()).To(Equal("replica"))
+		Expect(last()).To(Equal("replica-1"))
+		Expect(client.Set(ctx, "user", "alice", 0).Val()).To(Equal("OK"))
+		Expect(last()).To(Equal("master-1"))
+		Expect(client.Get(ctx, "key").Val()).To(Equal("replica"))
+		Expect(last()).To(Equal("replica-2"))
+		Expect(cluster.Stats().Moved).To(Equal(0))
+
+		// the replicas redirect the writes and the clients without READONLY
+		conn := dialRaw(cluster.node("replica-1").srv)
+		defer conn.Close()
+		Expect(conn.do("get", "user")).To(MatchError(HavePrefix("MOVED 5474")))
+		Expect(conn.do("readonly")).To(Equal("OK"))
+		Expect(conn.do("set", "user", "bob")).To(MatchError(HavePrefix("MOVED 5474")))
+	})
+
+	It("routes", func() {
+		for _, opt := range []*redis.ClusterOptions{{RouteByLatency: true}, {RouteRandomly: true}} {
+			opt.Addrs = cluster.Options().Addrs
+			client := redis.NewClusterClient(opt)
+
+			for i := 0; i < 5; i++ {
+				clusterMock.ExpectGet("key").SetVal("value")
+				Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+				Expect(last()).To(BeElementOf("master-2", "replica-2"))
+			}
+			Expect(client.Close()).NotTo(HaveOccurred())
+		}
+	})
+
+	It("roles", func() {
+		opt := cluster.Options()
+		opt.ReadOnly = true
+		client := redis.NewClusterClient(opt)
+		defer client.Close()
+
+		clusterMock.Node("master").ExpectSet("key", "1", 0).SetVal("OK")
+		clusterMock.Node("replica").ExpectGet("key").SetVal("1")
+		Expect(client.Set(ctx, "key", "1", 0).Val()).To(Equal("OK"))
+		Expect(client.Get(ctx, "key").Val()).To(Equal("1"))
+		Expect(cluster.Masters()).To(Equal([]string{"master-1", "master-2"}))
+		Expect(cluster.Replicas("master-2")).To(Equal([]string{"replica-2"}))
+	})
+
+	It("fan-out", func() {
+		client := redis.NewClusterClient(cluster.Options())
+		defer client.Close()
+
+		clusterMock.Node("master-1").ExpectDBSize().SetVal(10)
+		clusterMock.Node("master-2").ExpectDBSize().SetVal(20)
+		Expect(client.DBSize(ctx).Val()).To(Equal(int64(30)))
+
+		for _, name := range cluster.Masters() {
+			clusterMock.Node(name).ExpectFlushAll().SetVal("OK")
+		}
+		err := client.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+			return master.FlushAll(ctx).Err()
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, name := range []string{"master-1", "master-2", "replica-1", "replica-2"} {
+			clusterMock.Node(name).ExpectDBSize().SetVal(0)
+		}
+		err = client.ForEachShard(ctx, func(ctx context.Context, shard *redis.Client) error {
+			return shard.DBSize(ctx).Err()
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		nodes := map[string]int{}
+		for _, e := range clusterMock.History() {
+			if e.Expected != nil {
+				nodes[e.Node]++
+			}
+		}
+		Expect(nodes).To(Equal(map[string]int{"master-1": 3, "master-2": 3, "replica-1": 1, "replica-2": 1}))
+	})
+})
@@ -0,0 +1,202 @@
+package redismock
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ExpectedScanAll is the expectation of a full iteration of SCAN, SSCAN, HSCAN or ZSCAN over a set of elements.
+// It is matched by each call of the iteration, the cursor of a call must be the cursor returned by the
+// previous one, starting at 0, and it is met once the cursor 0 ending the iteration is returned.
+// The pages are filtered by the MATCH and TYPE options of the calls as Redis does, a page may be empty.
+type ExpectedScanAll struct {
+	expectedBase
+
+	key      string
+	elems    []scanElem
+	pageSize int
+	types    map[string]string
+
+	duplicates bool
+	emptyPages bool
+
+	pages [][]scanElem
+	next  uint64
+
+	// the reply of the matched call
+	page   []string
+	cursor uint64
+}
+
+// scanElem is an element of a scan, a key, a member, a field with its value or a member with its score.
+type scanElem struct {
+	name  string
+	reply []string
+}
+
+// ExpectScanAll expects the iteration of SCAN over the keys, in pages of pageSize keys.
+// The keys are strings for the TYPE option, see SetKeyType.
+func (m *mock) ExpectScanAll(keys []string, pageSize int) *ExpectedScanAll {
+	elems := make([]scanElem, len(keys))
+	for i, key := range keys {
+		elems[i] = scanElem{name: key, reply: []string{key}}
+	}
+	return m.expectScanAll(m.factory.Scan(m.ctx, 0, "", 0), "", elems, pageSize)
+}
+
+// ExpectSScanAll expects the iteration of SSCAN over the members of the set, in pages of pageSize members.
+func (m *mock) ExpectSScanAll(key string, members []string, pageSize int) *ExpectedScanAll {
+	elems := make([]scanElem, len(members))
+	for i, member := range members {
+		elems[i] = scanElem{name: member, reply: []string{member}}
+	}
+	return m.expectScanAll(m.factory.SScan(m.ctx, key, 0, "", 0), key, elems, pageSize)
+}
+
+// ExpectHScanAll expects the iteration of HSCAN over the fields of the hash, in pages of pageSize fields
+// ordered by name. The pages hold the fields followed by their values.
+func (m *mock) ExpectHScanAll(key string, fields map[string]string, pageSize int) *ExpectedScanAll {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	elems := make([]scanElem, len(names))
+	for i, name := range names {
+		elems[i] = scanElem{name: name, reply: []string{name, fields[name]}}
+	}
+	return m.expectScanAll(m.factory.HScan(m.ctx, key, 0, "", 0), key, elems, pageSize)
+}
+
+// ExpectZScanAll expects the iteration of ZSCAN over the members of the sorted set, in pages of pageSize
+// members. The pages hold the members followed by their scores.
+func (m *mock) ExpectZScanAll(key string, members []redis.Z, pageSize int) *ExpectedScanAll {
+	elems := make([]scanElem, len(members))
+	for i, z := range members {
+		member := fmt.Sprint(z.Member)
+		elems[i] = scanElem{name: member, reply: []string{member, formatFloat(z.Score)}}
+	}
+	return m.expectScanAll(m.factory.ZScan(m.ctx, key, 0, "", 0), key, elems, pageSize)
+}
+
+func (m *mock) expectScanAll(cmd redis.Cmder, key string, elems []scanElem, pageSize int) *ExpectedScanAll {
+	// the default COUNT of Redis
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	e := &ExpectedScanAll{key: key, elems: elems, pageSize: pageSize}
+	e.cmd = cmd
+	e.setVal = true
+	m.pushExpect(e)
+	return e
+}
+
+// SetKeyType sets the type of the keys for the TYPE option of SCAN, the keys are strings by default.
+func (e *ExpectedScanAll) SetKeyType(keyType string, keys ...string) *ExpectedScanAll {
+	if e.types == nil {
+		e.types = make(map[string]string)
+	}
+	for _, key := range keys {
+		e.types[key] = strings.ToLower(keyType)
+	}
+	return e
+}
+
+// Duplicates returns the last element of a page again at the start of the next page,
+// as Redis may return an element more than once when the keyspace is rehashed.
+func (e *ExpectedScanAll) Duplicates() *ExpectedScanAll {
+	e.duplicates = true
+	return e
+}
+
+// EmptyPages returns an empty page before each page, as Redis may when the slots of a cursor are empty.
+func (e *ExpectedScanAll) EmptyPages() *ExpectedScanAll {
+	e.emptyPages = true
+	return e
+}
+
+// split returns the pages of the iteration, the cursor of a page is its index.
+func (e *ExpectedScanAll) split() [][]scanElem {
+	var pages [][]scanElem
+	for i := 0; i < len(e.elems) || i == 0; i += e.pageSize {
+		end := i + e.pageSize
+		if end > len(e.elems) {
+			end = len(e.elems)
+		}
+		page := e.elems[i:end]
+		if e.duplicates && i > 0 {
+			page = append([]scanElem{e.elems[i-1]}, page...)
+		}
+		if e.emptyPages {
+			pages = append(pages, nil)
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// match checks the command and the cursor of the next call of the iteration, and prepares its page.
+func (e *ExpectedScanAll) match(cmd redis.Cmder) error {
+	args := formatArgs(cmd.Args())
+	pos := 1
+	if e.key != "" {
+		pos = 2
+	}
+	if cmd.Name() != e.name() || len(args) <= pos || (e.key != "" && args[1] != e.key) {
+		return fmt.Errorf("command not match, expectation '%s', but call to cmd '%+v'",
+			strings.TrimSpace(e.name()+" "+e.key), cmd.Args())
+	}
+	if args[pos] != strconv.FormatUint(e.next, 10) {
+		return fmt.Errorf("cursor not match, expectation cursor %d, but call to cmd '%+v'", e.next, cmd.Args())
+	}
+
+	match, keyType := "*", ""
+	for i := pos + 1; i+1 < len(args); i += 2 {
+		switch strings.ToLower(args[i]) {
+		case "match":
+			match = args[i+1]
+		case "type":
+			keyType = strings.ToLower(args[i+1])
+		}
+	}
+
+	if e.pages == nil {
+		e.pages = e.split()
+	}
+	e.page = []string{}
+	for _, elem := range e.pages[e.next] {
+		if !globMatch(match, elem.name) || (keyType != "" && e.keyType(elem.name) != keyType) {
+			continue
+		}
+		e.page = append(e.page, elem.reply...)
+	}
+	e.cursor = 0
+	if int(e.next)+1 < len(e.pages) {
+		e.cursor = e.next + 1
+	}
+	return nil
+}
+
+func (e *ExpectedScanAll) keyType(key string) string {
+	if typ, ok := e.types[key]; ok {
+		return typ
+	}
+	return "string"
+}
+
+// trigger moves to the next page, the expectation is met by the last one.
+func (e *ExpectedScanAll) trigger() {
+	e.next = e.cursor
+	if e.cursor == 0 {
+		e.triggered = true
+	}
+}
+
+func (e *ExpectedScanAll) inflow(c redis.Cmder) error {
+	return inflowPair(c, e.page, e.cursor)
+}
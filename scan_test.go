@@ -0,0 +1,120 @@
+package redismock
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("ScanAll", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+		keys       []string
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientMock()
+		keys = nil
+		for i := 0; i < 25; i++ {
+			keys = append(keys, fmt.Sprintf("user:%d", i))
+		}
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	iterate := func(it *redis.ScanIterator) []string {
+		vals := []string{}
+		for it.Next(ctx) {
+			vals = append(vals, it.Val())
+		}
+		Expect(it.Err()).NotTo(HaveOccurred())
+		return vals
+	}
+
+	It("iterator", func() {
+		clientMock.ExpectScanAll(keys, 10)
+		Expect(iterate(client.Scan(ctx, 0, "", 0).Iterator())).To(Equal(keys))
+		Expect(clientMock.History()).To(HaveLen(3))
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+
+		// a page
+		clientMock.ExpectScanAll(keys, 0)
+		page, cursor := client.Scan(ctx, 0, "", 100).Val()
+		Expect(page).To(Equal(keys[:10]))
+		Expect(cursor).To(Equal(uint64(1)))
+		Expect(clientMock.ExpectationsWereMet()).To(HaveOccurred())
+	})
+
+	It("filters", func() {
+		keys = append(keys, "session:1", "session:2")
+		clientMock.ExpectScanAll(keys, 10).SetKeyType("hash", "user:3", "user:13", "session:1")
+
+		vals := iterate(client.ScanType(ctx, 0, "user:?3", 5, "hash").Iterator())
+		Expect(vals).To(Equal([]string{"user:13"}))
+
+		// MATCH filters the pages
+		clientMock.ExpectScanAll(keys, 10)
+		page, cursor := client.Scan(ctx, 0, "session:*", 0).Val()
+		Expect(page).To(BeEmpty())
+		Expect(cursor).To(Equal(uint64(1)))
+		Expect(clientMock.ExpectationsWereMet()).To(HaveOccurred())
+	})
+
+	It("robustness", func() {
+		clientMock.ExpectScanAll(keys, 10).Duplicates().EmptyPages()
+
+		vals := iterate(client.Scan(ctx, 0, "", 0).Iterator())
+		Expect(vals).To(HaveLen(27))
+		Expect(vals).To(ContainElements(keys))
+		Expect(clientMock.History()).To(HaveLen(6))
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+
+		// no keys
+		clientMock.ExpectScanAll(nil, 10)
+		Expect(iterate(client.Scan(ctx, 0, "", 0).Iterator())).To(BeEmpty())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("collections", func() {
+		clientMock.ExpectSScanAll("set", []string{"a", "b", "c"}, 2)
+		Expect(iterate(client.SScan(ctx, "set", 0, "", 0).Iterator())).To(Equal([]string{"a", "b", "c"}))
+
+		clientMock.ExpectHScanAll("hash", map[string]string{"b": "2", "a": "1", "c": "3"}, 2)
+		Expect(iterate(client.HScan(ctx, "hash", 0, "[ab]", 0).Iterator())).To(Equal([]string{"a", "1", "b", "2"}))
+
+		clientMock.ExpectZScanAll("zset", []redis.Z{{Score: 1.5, Member: "a"}, {Score: 2, Member: "b"}}, 1)
+		Expect(iterate(client.ZScan(ctx, "zset", 0, "", 0).Iterator())).To(Equal([]string{"a", "1.5", "b", "2"}))
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("cursor", func() {
+		clientMock.ExpectScanAll(keys, 10)
+		Expect(client.Scan(ctx, 2, "", 0).Err()).To(MatchError(ContainSubstring("cursor not match")))
+		Expect(client.SScan(ctx, "set", 0, "", 0).Err()).To(MatchError(ContainSubstring("command not match")))
+
+		_, cursor := client.Scan(ctx, 0, "", 0).Val()
+		Expect(client.Scan(ctx, 0, "", 0).Err()).To(MatchError(ContainSubstring("cursor not match")))
+		_, cursor = client.Scan(ctx, cursor, "", 0).Val()
+		_, cursor = client.Scan(ctx, cursor, "", 0).Val()
+		Expect(cursor).To(BeZero())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("server", func() {
+		srv, err := NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+		defer srv.Close()
+		remote := redis.NewClient(srv.Options())
+		defer remote.Close()
+
+		clientMock.ExpectScanAll(keys, 10).EmptyPages()
+		Expect(iterate(remote.Scan(ctx, 0, "user:2*", 0).Iterator())).To(
+			Equal([]string{"user:2", "user:20", "user:21", "user:22", "user:23", "user:24"}))
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+})
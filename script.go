@@ -0,0 +1,159 @@
+package redismock
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ExpectedScript is the expectation of a Lua script run by EVAL of its source or by EVALSHA of its SHA1.
+// EVALSHA is answered NOSCRIPT until the script is loaded by SCRIPT LOAD or EVAL, and after SCRIPT FLUSH,
+// so redis.Script falls back to EVAL as it does against Redis.
+type ExpectedScript struct {
+	ExpectedCmd
+
+	src, sha string
+}
+
+// ExpectScript expects the script to run with the keys and args, by EVAL or EVALSHA.
+// Once a script is expected, the mock answers SCRIPT LOAD, SCRIPT EXISTS and SCRIPT FLUSH
+// that match no expectation.
+func (m *mock) ExpectScript(script string, keys []string, args ...interface{}) *ExpectedScript {
+	e := &ExpectedScript{src: script, sha: scriptSHA(script)}
+	e.cmd = m.factory.Eval(m.ctx, script, keys, args...)
+
+	m.scripts.expect()
+	m.pushExpect(e)
+	return e
+}
+
+// SHA1 returns the SHA1 of the script, as EVALSHA sends it.
+func (e *ExpectedScript) SHA1() string {
+	return e.sha
+}
+
+// match compares the command with the script, EVAL by the source and EVALSHA by the SHA1.
+func (e *ExpectedScript) match(cmd redis.Cmder) error {
+	expectArgs, cmdArgs := formatArgs(e.args()), formatArgs(cmd.Args())
+	if len(cmdArgs) < 2 {
+		return fmt.Errorf("command not match, expectation script '%s', but call to cmd '%+v'", e.sha, cmd.Args())
+	}
+
+	var ok bool
+	switch cmd.Name() {
+	case "eval":
+		ok = cmdArgs[1] == e.src
+	case "evalsha":
+		ok = strings.EqualFold(cmdArgs[1], e.sha)
+	}
+	if !ok || !reflect.DeepEqual(expectArgs[2:], cmdArgs[2:]) {
+		return fmt.Errorf("script not match, expectation '%s' with '%+v', but call to cmd '%+v'",
+			e.sha, expectArgs[2:], cmd.Args())
+	}
+	return nil
+}
+
+func scriptSHA(script string) string {
+	sum := sha1.Sum([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+//------------------------------------------------------------------------------
+
+// scripts is the script cache of the mock, the SHA1 of the scripts loaded by SCRIPT LOAD or EVAL.
+type scripts struct {
+	mu       sync.Mutex
+	expected bool
+	loaded   map[string]struct{}
+}
+
+func (s *scripts) expect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expected = true
+}
+
+func (s *scripts) load(script string) string {
+	sha := scriptSHA(script)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loaded == nil {
+		s.loaded = make(map[string]struct{})
+	}
+	s.loaded[sha] = struct{}{}
+	return sha
+}
+
+func (s *scripts) isLoaded(sha string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.loaded[strings.ToLower(sha)]
+	return ok
+}
+
+// run checks the script of an expectation matched by a command: EVALSHA of a script
+// not loaded is answered NOSCRIPT, and EVAL loads the script.
+func (s *scripts) run(e *ExpectedScript, cmd redis.Cmder) error {
+	if cmd.Name() == "evalsha" && !s.isLoaded(e.sha) {
+		return NoScriptError()
+	}
+	s.load(e.src)
+	return nil
+}
+
+// answers tells whether the SCRIPT commands are answered, once a script is expected.
+func (s *scripts) answers(cmd redis.Cmder) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.expected && cmd.Name() == "script"
+}
+
+// process answers SCRIPT LOAD, SCRIPT EXISTS and SCRIPT FLUSH once a script is expected,
+// ok is false for the other commands.
+func (s *scripts) process(cmd redis.Cmder) (ok bool, err error) {
+	args := formatArgs(cmd.Args())
+	if !s.answers(cmd) || len(args) < 2 {
+		return false, nil
+	}
+
+	w, wire := cmd.(*wireCmd)
+	switch sub := strings.ToLower(args[1]); {
+	case sub == "load" && len(args) == 3:
+		sha := s.load(args[2])
+		if wire {
+			w.reply = sha
+			return true, nil
+		}
+		return true, inflowReflect(cmd, sha)
+	case sub == "exists" && len(args) > 2:
+		exists := make([]bool, len(args)-2)
+		for i, sha := range args[2:] {
+			exists[i] = s.isLoaded(sha)
+		}
+		if wire {
+			replies := make([]interface{}, len(exists))
+			for i, ok := range exists {
+				replies[i] = encodeBool(ok)
+			}
+			w.reply = replies
+			return true, nil
+		}
+		return true, inflowReflect(cmd, exists)
+	case sub == "flush" && len(args) <= 3:
+		s.mu.Lock()
+		s.loaded = nil
+		s.mu.Unlock()
+		if wire {
+			w.reply = respStatus("OK")
+			return true, nil
+		}
+		return true, inflowReflect(cmd, "OK")
+	}
+	return false, nil
+}
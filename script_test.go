@@ -0,0 +1,81 @@
+package redismock
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Script", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+		script     *redis.Script
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientMock()
+		script = redis.NewScript("return redis.call('get', KEYS[1])")
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("fallback", func() {
+		e := clientMock.ExpectScript("return redis.call('get', KEYS[1])", []string{"key"})
+		e.SetVal("value")
+		Expect(e.SHA1()).To(Equal(script.Hash()))
+
+		Expect(script.Run(ctx, client, []string{"key"}).Val()).To(Equal("value"))
+
+		history := clientMock.History()
+		Expect(history).To(HaveLen(2))
+		Expect(history[0].Args[0]).To(Equal("evalsha"))
+		Expect(history[0].Err).To(MatchError(msgNoScript))
+		Expect(history[1].Args[0]).To(Equal("eval"))
+		Expect(history[1].Expected).To(BeIdenticalTo(e.cmd))
+
+		// EVAL loaded the script
+		clientMock.ExpectScript("return redis.call('get', KEYS[1])", []string{"key"}).SetVal("cached")
+		Expect(script.Run(ctx, client, []string{"key"}).Val()).To(Equal("cached"))
+		Expect(clientMock.History()).To(HaveLen(3))
+	})
+
+	It("load", func() {
+		clientMock.ExpectScript("return redis.call('get', KEYS[1])", []string{"key"}, "arg").SetVal("value")
+		Expect(script.Exists(ctx, client).Val()).To(Equal([]bool{false}))
+
+		Expect(script.Load(ctx, client).Val()).To(Equal(script.Hash()))
+		Expect(script.Exists(ctx, client).Val()).To(Equal([]bool{true}))
+		Expect(script.EvalSha(ctx, client, []string{"key"}, "arg").Val()).To(Equal("value"))
+
+		Expect(client.ScriptFlush(ctx).Err()).NotTo(HaveOccurred())
+		Expect(script.Exists(ctx, client).Val()).To(Equal([]bool{false}))
+
+		clientMock.ExpectScript("return redis.call('get', KEYS[1])", []string{"key"}, "arg").SetVal("value")
+		Expect(script.EvalSha(ctx, client, []string{"key"}, "arg").Err()).To(MatchError(msgNoScript))
+		Expect(script.Eval(ctx, client, []string{"key"}, "arg").Val()).To(Equal("value"))
+	})
+
+	It("arguments", func() {
+		clientMock.ExpectScript("return redis.call('get', KEYS[1])", []string{"key"}).SetVal("value")
+		Expect(script.Eval(ctx, client, []string{"other"}).Err()).To(MatchError(ContainSubstring("script not match")))
+		Expect(script.Eval(ctx, client, []string{"key"}).Val()).To(Equal("value"))
+	})
+
+	It("server", func() {
+		srv, err := NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+		defer srv.Close()
+		conn := dialRaw(srv)
+		defer conn.Close()
+
+		clientMock.ExpectScript("return redis.call('get', KEYS[1])", []string{"key"}).SetVal("value")
+		Expect(conn.do("evalsha", script.Hash(), 1, "key")).To(MatchError(msgNoScript))
+		Expect(conn.do("script", "load", "return redis.call('get', KEYS[1])")).To(Equal(script.Hash()))
+		Expect(conn.do("script", "exists", script.Hash(), "0000")).To(Equal([]interface{}{int64(1), int64(0)}))
+		Expect(conn.do("evalsha", script.Hash(), 1, "key")).To(Equal("value"))
+	})
+})
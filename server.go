@@ -0,0 +1,592 @@
+package redismock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Server exposes a mock over the network for the clients that cannot use the hook,
+// speaking RESP2 and RESP3. The commands are matched against the expectations of the mock
+// with their arguments as sent, and the replies are encoded from the Expected values.
+type Server struct {
+	m  *mock
+	ln net.Listener
+
+	// node is the node of a Cluster the server is
+	node *clusterNode
+
+	mu      sync.Mutex
+	clients map[int64]*respConn
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewServer starts a server for the mock listening on a free local TCP port.
+func NewServer(m baseMock) (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	return newServer(m, ln, nil), nil
+}
+
+// NewUnixServer starts a server for the mock listening on a Unix socket at path.
+func NewUnixServer(m baseMock, path string) (*Server, error) {
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return newServer(m, ln, nil), nil
+}
+
+func newServer(m baseMock, ln net.Listener, node *clusterNode) *Server {
+	s := &Server{
+		m:       m.(*mock),
+		ln:      ln,
+		node:    node,
+		clients: make(map[int64]*respConn),
+	}
+	if s.m.parent != nil {
+		s.m = s.m.parent
+	}
+	s.m.notifier.addServer(s)
+
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Addr returns the address the server listens on, "host:port" or the path of the socket.
+func (s *Server) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Network returns "tcp" or "unix".
+func (s *Server) Network() string {
+	return s.ln.Addr().Network()
+}
+
+// Options returns the options of a client connecting to the server.
+func (s *Server) Options() *redis.Options {
+	return &redis.Options{Network: s.Network(), Addr: s.Addr()}
+}
+
+// Close stops the server and closes the client connections.
+func (s *Server) Close() error {
+	s.m.notifier.removeServer(s)
+
+	s.mu.Lock()
+	s.closed = true
+	for _, c := range s.clients {
+		c.Close()
+	}
+	s.mu.Unlock()
+
+	err := s.ln.Close()
+	s.wg.Wait()
+	if s.Network() == "unix" {
+		_ = os.Remove(s.Addr())
+	}
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		c := newRESPConn(conn)
+		s.clients[c.id] = c
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			c.serve(s.handle)
+			if c.monitor != nil {
+				c.monitor()
+			}
+
+			s.mu.Lock()
+			delete(s.clients, c.id)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// client returns the connection of a client ID.
+func (s *Server) client(id int64) *respConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clients[id]
+}
+
+// handle executes a command of a connection, a transaction is queued
+// until EXEC and its commands are processed then.
+func (s *Server) handle(c *respConn, args []string) interface{} {
+	if s.node != nil {
+		if reply, ok := s.node.handle(c, args); ok {
+			// a redirection aborts the transaction
+			if c.multi && isReplyError(reply) {
+				c.dirty = true
+			}
+			return reply
+		}
+	}
+
+	name := protocolCommand(args)
+	switch {
+	case name == "client setinfo":
+		// sent by the clients on connect
+		return respStatus("OK")
+	case name == "client id":
+		return c.id
+	case name == "client caching":
+		if len(args) != 3 {
+			return errArity("client|caching")
+		}
+		c.caching = strings.ToLower(args[2])
+		return respStatus("OK")
+	case name == "monitor":
+		if c.monitor == nil {
+			c.monitor = s.m.history.monitor(func(line string) {
+				_ = c.write(respStatus(line))
+			})
+		}
+		return respStatus("OK")
+	case name == "subscribe" || name == "unsubscribe":
+		return c.subscribe(name == "subscribe", false, args[1:])
+	case name == "psubscribe" || name == "punsubscribe":
+		return c.subscribe(name == "psubscribe", true, args[1:])
+	case name == "multi":
+		if c.multi {
+			return respError("ERR MULTI calls can not be nested")
+		}
+		c.tx = true
+		if err := s.process(c, args); isReplyError(err) {
+			c.tx = false
+			return err
+		}
+		c.multi, c.dirty, c.queued = true, false, nil
+		return respStatus("OK")
+	case name == "discard":
+		if !c.multi {
+			return respError("ERR DISCARD without MULTI")
+		}
+		c.multi, c.queued = false, nil
+		defer func() { c.tx = false }()
+		return s.process(c, args)
+	case name == "exec":
+		if !c.multi {
+			return respError("ERR EXEC without MULTI")
+		}
+		queued, dirty := c.queued, c.dirty
+		c.multi, c.queued = false, nil
+		defer func() { c.tx = false }()
+		if s.m.crossSlot {
+			// the commands of a transaction are in one slot
+			var keys []string
+			for _, args := range queued {
+				keys = append(keys, commandKeys(args)...)
+			}
+			if _, err := keysSlot(keys); err != nil {
+				return err
+			}
+		}
+		if dirty {
+			return respError("EXECABORT Transaction discarded because of previous errors.")
+		}
+
+		replies := make([]interface{}, len(queued))
+		for i, args := range queued {
+			replies[i] = s.process(c, args)
+		}
+		if err := s.process(c, args); isReplyError(err) {
+			return err
+		}
+		return replies
+	case c.multi:
+		if _, err := keysSlot(commandKeys(args)); err != nil && s.m.crossSlot {
+			c.dirty = true
+			return err
+		}
+		c.queued = append(c.queued, args)
+		return respStatus("QUEUED")
+	}
+	if c.subscribed() && c.protocol() == 2 && name != "ping" && name != "quit" {
+		return respError("ERR Can't execute '" + name + "': only (P|S)SUBSCRIBE / (P|S)UNSUBSCRIBE / PING / QUIT / RESET are allowed in this context")
+	}
+	return s.process(c, args)
+}
+
+// subscribe changes the channels or the patterns the client receives the messages of,
+// UNSUBSCRIBE without channels unsubscribes from all of them.
+func (c *respConn) subscribe(on, pattern bool, channels []string) interface{} {
+	kind := "subscribe"
+	if !on {
+		kind = "unsubscribe"
+	}
+	if pattern {
+		kind = "p" + kind
+	}
+	if on && len(channels) == 0 {
+		return errArity(kind)
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.channels == nil {
+		c.channels = make(map[string]struct{})
+		c.patterns = make(map[string]struct{})
+	}
+	subs := c.channels
+	if pattern {
+		subs = c.patterns
+	}
+	if !on && len(channels) == 0 {
+		for ch := range subs {
+			channels = append(channels, ch)
+		}
+		sort.Strings(channels)
+	}
+
+	var replies respReplies
+	for _, ch := range channels {
+		if on {
+			subs[ch] = struct{}{}
+		} else {
+			delete(subs, ch)
+		}
+		replies = append(replies, respPush{kind, ch, int64(len(c.channels) + len(c.patterns))})
+	}
+	if len(replies) == 0 {
+		replies = append(replies, respPush{kind, nil, int64(0)})
+	}
+	return replies
+}
+
+func (c *respConn) subscribed() bool {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	return len(c.channels)+len(c.patterns) > 0
+}
+
+// publish sends a message to the clients subscribed to the channel and returns the number of deliveries.
+func (s *Server) publish(channel string, message interface{}) int64 {
+	s.mu.Lock()
+	clients := make([]*respConn, 0, len(s.clients))
+	for _, c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	var n int64
+	for _, c := range clients {
+		n += c.deliver(channel, message)
+	}
+	return n
+}
+
+// deliver writes the message of a channel if the client is subscribed to it or to a matching pattern.
+func (c *respConn) deliver(channel string, message interface{}) int64 {
+	c.wmu.Lock()
+	var msgs respReplies
+	if _, ok := c.channels[channel]; ok {
+		msgs = append(msgs, respPush{"message", channel, message})
+	}
+	for pattern := range c.patterns {
+		if globMatch(pattern, channel) {
+			msgs = append(msgs, respPush{"pmessage", pattern, channel, message})
+		}
+	}
+	c.wmu.Unlock()
+
+	if len(msgs) == 0 || c.write(msgs) != nil {
+		return 0
+	}
+	return int64(len(msgs))
+}
+
+func isReplyError(v interface{}) bool {
+	_, ok := v.(error)
+	return ok
+}
+
+func (s *Server) process(c *respConn, args []string) interface{} {
+	mode := processSingle
+	if c.tx {
+		mode = processTx
+	}
+	cmd := newWireCmd(c, args)
+	cmd.node = s.node
+	err := s.m.process(c.ctx, cmd, mode, nil)
+
+	if t, ok := cmd.matched.(*ExpectedTracking); ok && err == nil {
+		t.attach(s, c)
+	} else if c.tracking != nil {
+		c.tracking.track(c, args)
+	}
+
+	switch {
+	case err == redis.Nil:
+		return nil
+	case err != nil:
+		// the reply is a single line
+		return respError(strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+	return cmd.reply
+}
+
+//------------------------------------------------------------------------------
+
+// wireCmd is a command received by the Server, the reply is encoded from the Expected value.
+type wireCmd struct {
+	*redis.Cmd
+
+	conn    *respConn
+	node    *clusterNode
+	wire    []string
+	reply   interface{}
+	matched expectation
+}
+
+func newWireCmd(c *respConn, args []string) *wireCmd {
+	vals := make([]interface{}, len(args))
+	for i, arg := range args {
+		vals[i] = arg
+	}
+	return &wireCmd{
+		Cmd:  redis.NewCmd(context.Background(), vals...),
+		conn: c,
+		wire: args,
+	}
+}
+
+func (w *wireCmd) inflow(e expectation) error {
+	w.matched = e
+	reply, err := encodeExpectation(e, w.Name())
+	if err != nil {
+		return err
+	}
+	w.reply = protocolReply(w.conn.protocol(), w.wire, reply)
+	return nil
+}
+
+// value decodes the reply as the client reads it.
+func (w *wireCmd) value() interface{} {
+	if w.reply == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	wr := newRESPWriter(&buf, w.conn.protocol())
+	wr.write(w.reply)
+	if wr.flush() != nil {
+		return nil
+	}
+	val, _, err := decodeRESP(buf.Bytes())
+	if err != nil {
+		return nil
+	}
+	return val
+}
+
+// encodeExpectation returns the reply Redis sends for the value of an expectation.
+func encodeExpectation(e expectation, name string) (interface{}, error) {
+	switch e := e.(type) {
+	case *expectedFixture:
+		return e.fixture.Reply.resp(), nil
+	case *expectedGolden:
+		return encodeValue(goldenInterface(goldenJSON(e.val))), nil
+	}
+
+	// the value is set into a Cmd of the command type to be encoded
+	cmd := reflect.New(reflect.TypeOf(e.command()).Elem()).Interface().(redis.Cmder)
+	if err := e.inflow(cmd); err != nil {
+		return nil, err
+	}
+	return encodeReply(name, cmd)
+}
+
+// errNoEncoding is the error of encodeReply for the Cmd types it does not encode.
+var errNoEncoding = errors.New("the server cannot encode the reply")
+
+// encodeReply returns the reply Redis sends for the value of cmd.
+func encodeReply(name string, cmd redis.Cmder) (interface{}, error) {
+	switch cmd := cmd.(type) {
+	case *redis.Cmd:
+		return encodeValue(cmd.Val()), nil
+	case *redis.StatusCmd:
+		return respStatus(cmd.Val()), nil
+	case *redis.StringCmd:
+		return cmd.Val(), nil
+	case *redis.IntCmd:
+		return cmd.Val(), nil
+	case *redis.BoolCmd:
+		return encodeBool(cmd.Val()), nil
+	case *redis.FloatCmd:
+		return cmd.Val(), nil
+	case *redis.DurationCmd:
+		d := cmd.Val()
+		if d < 0 {
+			// -1 and -2 are sent as they are
+			return int64(d), nil
+		}
+		precision := time.Second
+		if strings.HasPrefix(name, "p") {
+			precision = time.Millisecond
+		}
+		return int64(d / precision), nil
+	case *redis.TimeCmd:
+		tm := cmd.Val()
+		return []interface{}{
+			strconv.FormatInt(tm.Unix(), 10),
+			strconv.FormatInt(int64(tm.Nanosecond()/1000), 10),
+		}, nil
+	case *redis.StringSliceCmd:
+		return cmd.Val(), nil
+	case *redis.IntSliceCmd:
+		return encodeValue(cmd.Val()), nil
+	case *redis.BoolSliceCmd:
+		vals := cmd.Val()
+		replies := make([]interface{}, len(vals))
+		for i, v := range vals {
+			replies[i] = encodeBool(v)
+		}
+		return replies, nil
+	case *redis.FloatSliceCmd:
+		return encodeValue(cmd.Val()), nil
+	case *redis.SliceCmd:
+		return encodeValue(cmd.Val()), nil
+	case *redis.StringStructMapCmd:
+		var members respSet
+		for member := range cmd.Val() {
+			members = append(members, member)
+		}
+		return members, nil
+	case *redis.MapStringStringCmd:
+		return encodeValue(cmd.Val()), nil
+	case *redis.MapStringIntCmd:
+		return encodeValue(cmd.Val()), nil
+	case *redis.MapStringInterfaceCmd:
+		return encodeValue(cmd.Val()), nil
+	case *redis.ZSliceCmd:
+		replies := respPairs{}
+		for _, z := range cmd.Val() {
+			replies = append(replies, fmt.Sprint(z.Member), z.Score)
+		}
+		return replies, nil
+	case *redis.ZWithKeyCmd:
+		z := cmd.Val()
+		return []interface{}{z.Key, fmt.Sprint(z.Member), z.Score}, nil
+	case *redis.ScanCmd:
+		keys, cursor := cmd.Val()
+		return []interface{}{strconv.FormatUint(cursor, 10), keys}, nil
+	case *redis.XMessageSliceCmd:
+		return encodeXMessages(cmd.Val()), nil
+	case *redis.XStreamSliceCmd:
+		var replies []interface{}
+		for _, stream := range cmd.Val() {
+			replies = append(replies, []interface{}{stream.Stream, encodeXMessages(stream.Messages)})
+		}
+		return replies, nil
+	case *redis.GeoPosCmd:
+		var replies []interface{}
+		for _, pos := range cmd.Val() {
+			if pos == nil {
+				replies = append(replies, respNilArr{})
+				continue
+			}
+			replies = append(replies, []interface{}{pos.Longitude, pos.Latitude})
+		}
+		return replies, nil
+	}
+	return nil, fmt.Errorf("redismock: %w of %T", errNoEncoding, cmd)
+}
+
+func encodeBool(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func encodeXMessages(msgs []redis.XMessage) []interface{} {
+	replies := make([]interface{}, len(msgs))
+	for i, msg := range msgs {
+		var values []interface{}
+		for field, value := range msg.Values {
+			values = append(values, field, fmt.Sprint(value))
+		}
+		replies[i] = []interface{}{msg.ID, values}
+	}
+	return replies
+}
+
+// encodeValue returns the reply for a value of redis.Cmd, or for a slice or map of basic values.
+func encodeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, string, int64, float64, bool, respStatus, respError:
+		return v
+	case int:
+		return int64(v)
+	case []interface{}:
+		replies := make([]interface{}, len(v))
+		for i, val := range v {
+			replies[i] = encodeValue(val)
+		}
+		return replies
+	case error:
+		return respError(v.Error())
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		replies := make([]interface{}, rv.Len())
+		for i := range replies {
+			replies[i] = encodeValue(rv.Index(i).Interface())
+		}
+		return replies
+	case reflect.Map:
+		// the keys are sorted for the flat array of RESP2
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		replies := respMap{}
+		for _, key := range keys {
+			replies = append(replies, encodeValue(key.Interface()), encodeValue(rv.MapIndex(key).Interface()))
+		}
+		return replies
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32:
+		return rv.Float()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,199 @@
+package redismock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		clientMock ClientMock
+		srv        *Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		_, clientMock = NewClientMock()
+		srv, err = NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(srv.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	for _, protocol := range []int{2, 3} {
+		protocol := protocol
+
+		It(fmt.Sprintf("resp%d", protocol), func() {
+			opt := srv.Options()
+			opt.Protocol = protocol
+			client := redis.NewClient(opt)
+			defer client.Close()
+
+			clientMock.ExpectSet("key", "value", time.Minute).SetVal("OK")
+			clientMock.ExpectGet("key").SetVal("value")
+			clientMock.ExpectGet("none").RedisNil()
+			clientMock.ExpectIncrBy("counter", 5).SetVal(5)
+			clientMock.ExpectTTL("key").SetVal(time.Minute)
+			clientMock.ExpectPTTL("none").SetVal(-2)
+			clientMock.ExpectZScore("z", "a").SetVal(1.5)
+			clientMock.ExpectHGetAll("hash").SetVal(map[string]string{"f1": "v1", "f2": "v2"})
+			clientMock.ExpectLRange("list", 0, -1).SetVal([]string{"a", "b"})
+			clientMock.ExpectSIsMember("set", "a").SetVal(true)
+			clientMock.ExpectZRangeWithScores("z", 0, -1).SetVal([]redis.Z{{Score: 1.5, Member: "a"}})
+			clientMock.ExpectScan(0, "*", 10).SetVal([]string{"key"}, 0)
+			clientMock.ExpectDo("custom", 1).SetVal([]interface{}{"a", int64(1)})
+			clientMock.ExpectGet("err").SetErr(errors.New("ERR failed\nsecond line"))
+
+			Expect(client.Set(ctx, "key", "value", time.Minute).Val()).To(Equal("OK"))
+			Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+			Expect(client.Get(ctx, "none").Err()).To(Equal(redis.Nil))
+			Expect(client.IncrBy(ctx, "counter", 5).Val()).To(Equal(int64(5)))
+			Expect(client.TTL(ctx, "key").Val()).To(Equal(time.Minute))
+			Expect(client.PTTL(ctx, "none").Val()).To(Equal(time.Duration(-2)))
+			Expect(client.ZScore(ctx, "z", "a").Val()).To(Equal(1.5))
+			Expect(client.HGetAll(ctx, "hash").Val()).To(Equal(map[string]string{"f1": "v1", "f2": "v2"}))
+			Expect(client.LRange(ctx, "list", 0, -1).Val()).To(Equal([]string{"a", "b"}))
+			Expect(client.SIsMember(ctx, "set", "a").Val()).To(BeTrue())
+			Expect(client.ZRangeWithScores(ctx, "z", 0, -1).Val()).To(Equal([]redis.Z{{Score: 1.5, Member: "a"}}))
+
+			keys, cursor, err := client.Scan(ctx, 0, "*", 10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"key"}))
+			Expect(cursor).To(Equal(uint64(0)))
+
+			Expect(client.Do(ctx, "custom", 1).Val()).To(Equal([]interface{}{"a", int64(1)}))
+			Expect(client.Get(ctx, "err").Err()).To(MatchError("ERR failed second line"))
+		})
+
+		It(fmt.Sprintf("tx pipeline resp%d", protocol), func() {
+			opt := srv.Options()
+			opt.Protocol = protocol
+			client := redis.NewClient(opt)
+			defer client.Close()
+
+			clientMock.ExpectTxPipeline()
+			clientMock.ExpectSet("key", "1", 0).SetVal("OK")
+			clientMock.ExpectIncr("key").SetVal(2)
+			clientMock.ExpectTxPipelineExec()
+
+			var incr *redis.IntCmd
+			_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, "key", "1", 0)
+				incr = pipe.Incr(ctx, "key")
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(incr.Val()).To(Equal(int64(2)))
+		})
+	}
+
+	It("unexpected", func() {
+		client := redis.NewClient(srv.Options())
+		defer client.Close()
+
+		err := client.Get(ctx, "key").Err()
+		Expect(err).To(MatchError(ContainSubstring("was not expected")))
+		unexpected, _ := clientMock.UnexpectedCallsWereMade()
+		Expect(unexpected).To(BeTrue())
+	})
+
+	It("concurrent connections", func() {
+		const conns, calls = 8, 20
+		clientMock.MatchExpectationsInOrder(false)
+		for i := 0; i < conns*calls; i++ {
+			clientMock.ExpectIncr("counter").SetVal(int64(i))
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < conns; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				opt := srv.Options()
+				opt.PoolSize = 1
+				client := redis.NewClient(opt)
+				defer client.Close()
+
+				for j := 0; j < calls; j++ {
+					Expect(client.Incr(ctx, "counter").Err()).NotTo(HaveOccurred())
+					Expect(client.Get(ctx, "key").Err()).To(MatchError(ContainSubstring("was not expected")))
+					clientMock.UnexpectedCallsWereMade()
+				}
+			}()
+		}
+		wg.Wait()
+
+		_, unexpected := clientMock.UnexpectedCallsWereMade()
+		Expect(unexpected).To(HaveLen(conns * calls))
+	})
+
+	It("regexp", func() {
+		client := redis.NewClient(srv.Options())
+		defer client.Close()
+
+		clientMock.Regexp().ExpectSet("key", `^v\d+$`, 0).SetVal("OK")
+		Expect(client.Set(ctx, "key", "v10", 0).Val()).To(Equal("OK"))
+	})
+
+	It("map args", func() {
+		client := redis.NewClient(srv.Options())
+		defer client.Close()
+
+		clientMock.ExpectHSet("hash", "f1", "v1", "f2", "v2").SetVal(2)
+		Expect(client.HSet(ctx, "hash", "f2", "v2", "f1", "v1").Val()).To(Equal(int64(2)))
+	})
+
+	It("unix socket", func() {
+		dir, err := os.MkdirTemp("", "redismock")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		unix, err := NewUnixServer(clientMock, filepath.Join(dir, "redis.sock"))
+		Expect(err).NotTo(HaveOccurred())
+		defer unix.Close()
+		Expect(unix.Network()).To(Equal("unix"))
+
+		client := redis.NewClient(unix.Options())
+		defer client.Close()
+
+		clientMock.ExpectPing().SetVal("PONG")
+		Expect(client.Ping(ctx).Val()).To(Equal("PONG"))
+	})
+
+	It("fake mock", func() {
+		_, fakeMock := NewClientFakeMock()
+		fakeSrv, err := NewServer(fakeMock)
+		Expect(err).NotTo(HaveOccurred())
+		defer fakeSrv.Close()
+
+		client := redis.NewClient(fakeSrv.Options())
+		defer client.Close()
+
+		fakeMock.ExpectGet("key").SetVal("mocked")
+		Expect(client.Set(ctx, "key", "1", 0).Val()).To(Equal("OK"))
+		Expect(client.Get(ctx, "key").Val()).To(Equal("mocked"))
+		Expect(client.Incr(ctx, "key").Val()).To(Equal(int64(2)))
+		Expect(client.Get(ctx, "none").Err()).To(Equal(redis.Nil))
+
+		_, err = client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Incr(ctx, "key")
+			pipe.Incr(ctx, "key")
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "key").Val()).To(Equal("4"))
+		Expect(fakeMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+})
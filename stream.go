@@ -0,0 +1,1162 @@
+package redismock
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streamID is the ID of a stream entry, a timestamp in milliseconds and a sequence number.
+type streamID struct {
+	ms, seq uint64
+}
+
+func (id streamID) String() string {
+	return strconv.FormatUint(id.ms, 10) + "-" + strconv.FormatUint(id.seq, 10)
+}
+
+func (id streamID) less(other streamID) bool {
+	return id.ms < other.ms || (id.ms == other.ms && id.seq < other.seq)
+}
+
+// parseStreamID parses "<ms>-<seq>" or "<ms>", seq is the sequence of an ID without one.
+func parseStreamID(s string, seq uint64) (streamID, bool) {
+	ms, rest, found := strings.Cut(s, "-")
+	var id streamID
+	var err error
+	if id.ms, err = strconv.ParseUint(ms, 10, 64); err != nil {
+		return id, false
+	}
+	id.seq = seq
+	if found {
+		if id.seq, err = strconv.ParseUint(rest, 10, 64); err != nil {
+			return id, false
+		}
+	}
+	return id, true
+}
+
+// parseStreamBound parses a bound of XRANGE, "-", "+" or an ID, exclusive with "(".
+func parseStreamBound(s string, end bool) (id streamID, ok bool) {
+	switch s {
+	case "-":
+		return streamID{}, true
+	case "+":
+		return streamID{math.MaxUint64, math.MaxUint64}, true
+	}
+
+	exclusive := strings.HasPrefix(s, "(")
+	seq := uint64(0)
+	if end {
+		seq = math.MaxUint64
+	}
+	if id, ok = parseStreamID(strings.TrimPrefix(s, "("), seq); !ok || !exclusive {
+		return id, ok
+	}
+	switch {
+	case !end && id.seq < math.MaxUint64:
+		id.seq++
+	case !end && id.ms < math.MaxUint64:
+		id = streamID{id.ms + 1, 0}
+	case end && id.seq > 0:
+		id.seq--
+	case end && id.ms > 0:
+		id = streamID{id.ms - 1, math.MaxUint64}
+	default:
+		return id, false
+	}
+	return id, true
+}
+
+const (
+	errStreamID      = respError("ERR Invalid stream ID specified as stream command argument")
+	errStreamTop     = respError("ERR The ID specified in XADD is equal or smaller than the target stream top item")
+	errStreamNoKey   = respError("ERR The XGROUP subcommand requires the key to exist. Note that for CREATE you may want to use the MKSTREAM option to create an empty stream automatically.")
+	errBusyGroup     = respError("BUSYGROUP Consumer Group name already exists")
+	errStreamMinIdle = respError("ERR Invalid min-idle-time argument for XCLAIM")
+)
+
+func errNoGroup(key, group string) respError {
+	return respError("NOGROUP No such key '" + key + "' or consumer group '" + group + "'")
+}
+
+//------------------------------------------------------------------------------
+
+// fakeStream is a stream of the fake, its entries ordered by ID and its consumer groups.
+type fakeStream struct {
+	entries []streamEntry
+	last    streamID
+	// added counts the entries added to the stream, for the lag of the groups
+	added  int64
+	groups map[string]*fakeGroup
+}
+
+type streamEntry struct {
+	id     streamID
+	fields []string
+}
+
+func (e streamEntry) reply() []interface{} {
+	return []interface{}{e.id.String(), e.fields}
+}
+
+// fakeGroup is a consumer group, the last ID delivered and the pending entries list ordered by ID.
+type fakeGroup struct {
+	lastID streamID
+	// read counts the entries delivered to the group, -1 when it is unknown after XGROUP SETID
+	read      int64
+	pending   []*fakePending
+	consumers map[string]*fakeConsumer
+}
+
+// fakePending is an entry delivered to a consumer and not acknowledged yet.
+type fakePending struct {
+	id        streamID
+	consumer  string
+	delivered time.Time
+	count     int64
+}
+
+// fakeConsumer is a consumer of a group, seen by its last command and active by its last delivery.
+type fakeConsumer struct {
+	seen, active time.Time
+}
+
+func (s *fakeStore) getStream(key string, create bool) (*fakeStream, error) {
+	e := s.get(key)
+	if e == nil {
+		if !create {
+			return nil, nil
+		}
+		e = s.set(key, &fakeStream{})
+	}
+	v, ok := e.val.(*fakeStream)
+	if !ok {
+		return nil, errWrongType
+	}
+	return v, nil
+}
+
+// getGroup returns the group of a stream, nil if the key or the group does not exist.
+func (s *fakeStore) getGroup(key, group string) (*fakeStream, *fakeGroup, error) {
+	st, err := s.getStream(key, false)
+	if st == nil || err != nil {
+		return nil, nil, err
+	}
+	return st, st.groups[group], nil
+}
+
+// search returns the index of the first entry not less than id.
+func (st *fakeStream) search(id streamID) int {
+	return sort.Search(len(st.entries), func(i int) bool {
+		return !st.entries[i].id.less(id)
+	})
+}
+
+func (st *fakeStream) entry(id streamID) (streamEntry, bool) {
+	i := st.search(id)
+	if i < len(st.entries) && st.entries[i].id == id {
+		return st.entries[i], true
+	}
+	return streamEntry{}, false
+}
+
+// between returns the entries from lo to hi, at most count unless count is negative.
+func (st *fakeStream) between(lo, hi streamID, count int) []streamEntry {
+	var entries []streamEntry
+	for i := st.search(lo); i < len(st.entries) && !hi.less(st.entries[i].id); i++ {
+		if count >= 0 && len(entries) == count {
+			break
+		}
+		entries = append(entries, st.entries[i])
+	}
+	return entries
+}
+
+// after returns the entries after id, at most count unless count is negative.
+func (st *fakeStream) after(id streamID, count int) []streamEntry {
+	lo, ok := parseStreamBound("("+id.String(), false)
+	if !ok {
+		return nil
+	}
+	return st.between(lo, streamID{math.MaxUint64, math.MaxUint64}, count)
+}
+
+// nextID returns the ID of XADD, "*" and "<ms>-*" are generated from the time and the last ID.
+func (st *fakeStream) nextID(arg string, now time.Time) (streamID, error) {
+	if arg == "*" {
+		ms := uint64(now.UnixNano() / int64(time.Millisecond))
+		if ms <= st.last.ms {
+			return streamID{st.last.ms, st.last.seq + 1}, nil
+		}
+		return streamID{ms, 0}, nil
+	}
+
+	if ms := strings.TrimSuffix(arg, "-*"); ms != arg {
+		id, ok := parseStreamID(ms, 0)
+		switch {
+		case !ok:
+			return id, errStreamID
+		case id.ms < st.last.ms:
+			return id, errStreamTop
+		case id.ms == st.last.ms && st.last != (streamID{}):
+			id.seq = st.last.seq + 1
+		case id.ms == 0:
+			id.seq = 1
+		}
+		return id, nil
+	}
+
+	id, ok := parseStreamID(arg, 0)
+	switch {
+	case !ok:
+		return id, errStreamID
+	case id == (streamID{}):
+		return id, respError("ERR The ID specified in XADD must be greater than 0-0")
+	case !st.last.less(id):
+		return id, errStreamTop
+	}
+	return id, nil
+}
+
+// streamTrim is the MAXLEN or MINID option of XADD and XTRIM, LIMIT is accepted and ignored
+// as the fake trims exactly.
+type streamTrim struct {
+	minID  bool
+	maxLen int
+	min    streamID
+}
+
+// parseStreamTrim parses the trimming option at args[i], it returns the index following it.
+func parseStreamTrim(args []string, i int) (*streamTrim, int, error) {
+	t := &streamTrim{minID: strings.EqualFold(args[i], "minid")}
+	i++
+	if i < len(args) && (args[i] == "=" || args[i] == "~") {
+		i++
+	}
+	if i >= len(args) {
+		return nil, i, errSyntax
+	}
+	if t.minID {
+		id, ok := parseStreamID(args[i], 0)
+		if !ok {
+			return nil, i, errStreamID
+		}
+		t.min = id
+	} else {
+		n, err := strconv.Atoi(args[i])
+		if err != nil || n < 0 {
+			return nil, i, respError("ERR The MAXLEN argument must be >= 0.")
+		}
+		t.maxLen = n
+	}
+	i++
+	if i+1 < len(args) && strings.EqualFold(args[i], "limit") {
+		if _, err := strconv.Atoi(args[i+1]); err != nil {
+			return nil, i, errNotInt
+		}
+		i += 2
+	}
+	return t, i, nil
+}
+
+// trim removes the entries beyond the option and returns their count.
+func (st *fakeStream) trim(t *streamTrim) int64 {
+	n := 0
+	if t.minID {
+		n = st.search(t.min)
+	} else if len(st.entries) > t.maxLen {
+		n = len(st.entries) - t.maxLen
+	}
+	st.entries = st.entries[n:]
+	return int64(n)
+}
+
+func (g *fakeGroup) consumer(name string, now time.Time) *fakeConsumer {
+	if g.consumers == nil {
+		g.consumers = make(map[string]*fakeConsumer)
+	}
+	c, ok := g.consumers[name]
+	if !ok {
+		c = &fakeConsumer{}
+		g.consumers[name] = c
+	}
+	c.seen = now
+	return c
+}
+
+// search returns the index of the first pending entry not less than id.
+func (g *fakeGroup) search(id streamID) int {
+	return sort.Search(len(g.pending), func(i int) bool {
+		return !g.pending[i].id.less(id)
+	})
+}
+
+func (g *fakeGroup) lookup(id streamID) *fakePending {
+	i := g.search(id)
+	if i < len(g.pending) && g.pending[i].id == id {
+		return g.pending[i]
+	}
+	return nil
+}
+
+// deliver adds an entry to the pending entries list of a consumer, or assigns it to the consumer.
+func (g *fakeGroup) deliver(id streamID, consumer string, now time.Time) *fakePending {
+	if p := g.lookup(id); p != nil {
+		p.consumer, p.delivered = consumer, now
+		p.count++
+		return p
+	}
+	p := &fakePending{id: id, consumer: consumer, delivered: now, count: 1}
+	i := g.search(id)
+	g.pending = append(g.pending, nil)
+	copy(g.pending[i+1:], g.pending[i:])
+	g.pending[i] = p
+	return p
+}
+
+func (g *fakeGroup) ack(id streamID) bool {
+	i := g.search(id)
+	if i == len(g.pending) || g.pending[i].id != id {
+		return false
+	}
+	g.pending = append(g.pending[:i], g.pending[i+1:]...)
+	return true
+}
+
+func (g *fakeGroup) pendingOf(consumer string) int64 {
+	var n int64
+	for _, p := range g.pending {
+		if p.consumer == consumer {
+			n++
+		}
+	}
+	return n
+}
+
+//------------------------------------------------------------------------------
+
+func fakeXAdd(s *fakeStore, args []string) interface{} {
+	key := args[0]
+	var nomkstream bool
+	var trim *streamTrim
+
+	i := 1
+options:
+	for ; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "nomkstream":
+			nomkstream = true
+		case "maxlen", "minid":
+			var err error
+			if trim, i, err = parseStreamTrim(args, i); err != nil {
+				return err
+			}
+			i--
+		default:
+			break options
+		}
+	}
+	if i >= len(args) || (len(args)-i-1)%2 != 0 || len(args)-i-1 == 0 {
+		return errArity("xadd")
+	}
+
+	st, err := s.getStream(key, false)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		if nomkstream {
+			return nil
+		}
+		st = &fakeStream{}
+	}
+	id, err := st.nextID(args[i], s.now())
+	if err != nil {
+		return err
+	}
+	if s.get(key) == nil {
+		s.set(key, st)
+	}
+
+	st.entries = append(st.entries, streamEntry{id: id, fields: append([]string(nil), args[i+1:]...)})
+	st.last = id
+	st.added++
+	if trim != nil {
+		st.trim(trim)
+	}
+	return id.String()
+}
+
+func fakeXLen(s *fakeStore, args []string) interface{} {
+	st, err := s.getStream(args[0], false)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		return int64(0)
+	}
+	return int64(len(st.entries))
+}
+
+func fakeXRange(rev bool) func(s *fakeStore, args []string) interface{} {
+	return func(s *fakeStore, args []string) interface{} {
+		start, end := args[1], args[2]
+		if rev {
+			start, end = end, start
+		}
+		lo, ok := parseStreamBound(start, false)
+		hi, ok2 := parseStreamBound(end, true)
+		if !ok || !ok2 {
+			return errStreamID
+		}
+		count := -1
+		if len(args) > 3 {
+			if len(args) != 5 || !strings.EqualFold(args[3], "count") {
+				return errSyntax
+			}
+			n, err := strconv.Atoi(args[4])
+			if err != nil {
+				return errNotInt
+			}
+			if count = n; count < 0 {
+				count = -1
+			}
+		}
+
+		st, err := s.getStream(args[0], false)
+		if err != nil {
+			return err
+		}
+		replies := []interface{}{}
+		if st == nil || hi.less(lo) {
+			return replies
+		}
+		entries := st.between(lo, hi, -1)
+		if rev {
+			for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+				entries[i], entries[j] = entries[j], entries[i]
+			}
+		}
+		for _, e := range entries {
+			if count >= 0 && len(replies) == count {
+				break
+			}
+			replies = append(replies, e.reply())
+		}
+		return replies
+	}
+}
+
+func fakeXDel(s *fakeStore, args []string) interface{} {
+	ids := make([]streamID, len(args)-1)
+	for i, arg := range args[1:] {
+		id, ok := parseStreamID(arg, 0)
+		if !ok {
+			return errStreamID
+		}
+		ids[i] = id
+	}
+	st, err := s.getStream(args[0], false)
+	if st == nil || err != nil {
+		return nilOr(err, int64(0))
+	}
+
+	var n int64
+	for _, id := range ids {
+		i := st.search(id)
+		if i < len(st.entries) && st.entries[i].id == id {
+			st.entries = append(st.entries[:i], st.entries[i+1:]...)
+			n++
+		}
+	}
+	return n
+}
+
+func fakeXTrim(s *fakeStore, args []string) interface{} {
+	if !strings.EqualFold(args[1], "maxlen") && !strings.EqualFold(args[1], "minid") {
+		return errSyntax
+	}
+	trim, i, err := parseStreamTrim(args, 1)
+	if err != nil {
+		return err
+	}
+	if i != len(args) {
+		return errSyntax
+	}
+	st, err := s.getStream(args[0], false)
+	if st == nil || err != nil {
+		return nilOr(err, int64(0))
+	}
+	return st.trim(trim)
+}
+
+func fakeXSetID(s *fakeStore, args []string) interface{} {
+	id, ok := parseStreamID(args[1], 0)
+	if !ok {
+		return errStreamID
+	}
+	st, err := s.getStream(args[0], false)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		return errNoSuchKey
+	}
+	if len(st.entries) > 0 && id.less(st.entries[len(st.entries)-1].id) {
+		return respError("ERR The ID specified in XSETID is smaller than the target stream top item")
+	}
+	st.last = id
+	return respStatus("OK")
+}
+
+// nilOr returns the error if any, else the reply.
+func nilOr(err error, reply interface{}) interface{} {
+	if err != nil {
+		return err
+	}
+	return reply
+}
+
+// streamsArgs parses the options of XREAD and XREADGROUP before STREAMS, handled by option,
+// and returns the keys and the IDs following STREAMS.
+func streamsArgs(name string, args []string, option func(args []string, i int) (int, error)) (keys, ids []string, err error) {
+	for i := 0; i < len(args); i++ {
+		if strings.EqualFold(args[i], "streams") {
+			streams := args[i+1:]
+			if len(streams) == 0 || len(streams)%2 != 0 {
+				return nil, nil, respError("ERR Unbalanced '" + name + "' list of streams: for each stream key an ID or '$' must be specified.")
+			}
+			return streams[:len(streams)/2], streams[len(streams)/2:], nil
+		}
+		if i, err = option(args, i); err != nil {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, errSyntax
+}
+
+// streamCount parses COUNT and BLOCK, the fake does not block: the reply is nil at once without entries.
+func streamCount(count *int) func(args []string, i int) (int, error) {
+	return func(args []string, i int) (int, error) {
+		switch strings.ToLower(args[i]) {
+		case "count", "block":
+			if i+1 >= len(args) {
+				return i, errSyntax
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return i, errNotInt
+			}
+			if strings.EqualFold(args[i], "count") && n > 0 {
+				*count = n
+			}
+			return i + 1, nil
+		}
+		return i, errSyntax
+	}
+}
+
+func fakeXRead(s *fakeStore, args []string) interface{} {
+	count := -1
+	keys, ids, err := streamsArgs("xread", args, streamCount(&count))
+	if err != nil {
+		return err
+	}
+
+	replies := []interface{}{}
+	for i, key := range keys {
+		st, err := s.getStream(key, false)
+		if err != nil {
+			return err
+		}
+		var id streamID
+		switch {
+		case ids[i] == "$":
+			if st != nil {
+				id = st.last
+			}
+		default:
+			var ok bool
+			if id, ok = parseStreamID(ids[i], 0); !ok {
+				return errStreamID
+			}
+		}
+		if st == nil {
+			continue
+		}
+		if entries := st.after(id, count); len(entries) > 0 {
+			replies = append(replies, []interface{}{key, entryReplies(entries)})
+		}
+	}
+	if len(replies) == 0 {
+		return respNilArr{}
+	}
+	return replies
+}
+
+func entryReplies(entries []streamEntry) []interface{} {
+	replies := make([]interface{}, len(entries))
+	for i, e := range entries {
+		replies[i] = e.reply()
+	}
+	return replies
+}
+
+func fakeXReadGroup(s *fakeStore, args []string) interface{} {
+	if len(args) < 3 || !strings.EqualFold(args[0], "group") {
+		return errSyntax
+	}
+	group, consumer := args[1], args[2]
+	count, noack := -1, false
+	options := streamCount(&count)
+	keys, ids, err := streamsArgs("xreadgroup", args[3:], func(args []string, i int) (int, error) {
+		if strings.EqualFold(args[i], "noack") {
+			noack = true
+			return i, nil
+		}
+		return options(args, i)
+	})
+	if err != nil {
+		return err
+	}
+
+	// the groups are checked before any delivery
+	groups := make([]*fakeGroup, len(keys))
+	streams := make([]*fakeStream, len(keys))
+	for i, key := range keys {
+		st, g, err := s.getGroup(key, group)
+		if err != nil {
+			return err
+		}
+		if g == nil {
+			return respError("NOGROUP No such key '" + key + "' or consumer group '" + group +
+				"' in XREADGROUP with GROUP option")
+		}
+		if ids[i] != ">" {
+			if _, ok := parseStreamID(ids[i], 0); !ok {
+				return errStreamID
+			}
+		}
+		streams[i], groups[i] = st, g
+	}
+
+	now := s.now()
+	replies := []interface{}{}
+	for i, key := range keys {
+		st, g := streams[i], groups[i]
+		c := g.consumer(consumer, now)
+
+		if ids[i] != ">" {
+			// the history of the consumer, the deleted entries have no fields,
+			// the delivery counts are not changed
+			id, _ := parseStreamID(ids[i], 0)
+			history := []interface{}{}
+			for _, p := range g.pending[g.search(id):] {
+				if p.consumer != consumer || p.id == id {
+					continue
+				}
+				if count >= 0 && len(history) == count {
+					break
+				}
+				if e, ok := st.entry(p.id); ok {
+					history = append(history, e.reply())
+				} else {
+					history = append(history, []interface{}{p.id.String(), nil})
+				}
+			}
+			replies = append(replies, []interface{}{key, history})
+			continue
+		}
+
+		entries := st.after(g.lastID, count)
+		if len(entries) == 0 {
+			continue
+		}
+		for _, e := range entries {
+			g.lastID = e.id
+			if g.read >= 0 {
+				g.read++
+			}
+			if !noack {
+				g.deliver(e.id, consumer, now)
+			}
+		}
+		c.active = now
+		replies = append(replies, []interface{}{key, entryReplies(entries)})
+	}
+	if len(replies) == 0 {
+		return respNilArr{}
+	}
+	return replies
+}
+
+func fakeXAck(s *fakeStore, args []string) interface{} {
+	ids := make([]streamID, len(args)-2)
+	for i, arg := range args[2:] {
+		id, ok := parseStreamID(arg, 0)
+		if !ok {
+			return errStreamID
+		}
+		ids[i] = id
+	}
+	_, g, err := s.getGroup(args[0], args[1])
+	if g == nil || err != nil {
+		return nilOr(err, int64(0))
+	}
+
+	var n int64
+	for _, id := range ids {
+		if g.ack(id) {
+			n++
+		}
+	}
+	return n
+}
+
+func fakeXGroup(s *fakeStore, args []string) interface{} {
+	sub := strings.ToLower(args[0])
+	arity := map[string]int{"create": 4, "setid": 4, "destroy": 3, "createconsumer": 4, "delconsumer": 4}
+	n, ok := arity[sub]
+	if !ok {
+		return respError("ERR unknown subcommand '" + args[0] + "'. Try XGROUP HELP.")
+	}
+	if len(args) < n || (sub != "create" && sub != "setid" && len(args) != n) {
+		return errArity("xgroup|" + sub)
+	}
+	key, group := args[1], args[2]
+
+	st, err := s.getStream(key, false)
+	if err != nil {
+		return err
+	}
+	if sub == "create" {
+		return s.xgroupCreate(st, key, group, args[3:])
+	}
+	if st == nil {
+		return errStreamNoKey
+	}
+	g := st.groups[group]
+	if g == nil && sub != "destroy" {
+		return respError("NOGROUP No such consumer group '" + group + "' for key name '" + key + "'")
+	}
+
+	switch sub {
+	case "setid":
+		id, read, err := groupID(st, args[3:])
+		if err != nil {
+			return err
+		}
+		g.lastID, g.read = id, read
+		return respStatus("OK")
+	case "destroy":
+		if g == nil {
+			return int64(0)
+		}
+		delete(st.groups, group)
+		return int64(1)
+	case "createconsumer":
+		if _, ok := g.consumers[args[3]]; ok {
+			return int64(0)
+		}
+		g.consumer(args[3], s.now())
+		return int64(1)
+	}
+
+	// delconsumer, its pending entries are deleted
+	consumer := args[3]
+	if _, ok := g.consumers[consumer]; !ok {
+		return int64(0)
+	}
+	pending := g.pending[:0]
+	for _, p := range g.pending {
+		if p.consumer != consumer {
+			pending = append(pending, p)
+		}
+	}
+	deleted := int64(len(g.pending) - len(pending))
+	g.pending = pending
+	delete(g.consumers, consumer)
+	return deleted
+}
+
+func (s *fakeStore) xgroupCreate(st *fakeStream, key, group string, args []string) interface{} {
+	var mkstream bool
+	var rest []string
+	for i, arg := range args {
+		if i > 0 && strings.EqualFold(arg, "mkstream") {
+			mkstream = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	if st == nil {
+		if !mkstream {
+			return errStreamNoKey
+		}
+		st = &fakeStream{}
+	}
+	if _, ok := st.groups[group]; ok {
+		return errBusyGroup
+	}
+	id, read, err := groupID(st, rest)
+	if err != nil {
+		return err
+	}
+	if s.get(key) == nil {
+		s.set(key, st)
+	}
+
+	if st.groups == nil {
+		st.groups = make(map[string]*fakeGroup)
+	}
+	st.groups[group] = &fakeGroup{lastID: id, read: read}
+	return respStatus("OK")
+}
+
+// groupID parses the ID of XGROUP CREATE and SETID with ENTRIESREAD, it returns the entries read:
+// all of them from $, none from 0, unknown otherwise.
+func groupID(st *fakeStream, args []string) (streamID, int64, error) {
+	if len(args) != 1 && (len(args) != 3 || !strings.EqualFold(args[1], "entriesread")) {
+		return streamID{}, 0, errSyntax
+	}
+
+	var id streamID
+	read := int64(-1)
+	switch args[0] {
+	case "$":
+		id, read = st.last, st.added
+	default:
+		var ok bool
+		if id, ok = parseStreamID(args[0], 0); !ok {
+			return id, 0, errStreamID
+		}
+		if id == (streamID{}) {
+			read = 0
+		}
+	}
+	if len(args) == 3 {
+		n, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil || n < -1 {
+			return id, 0, respError("ERR value for ENTRIESREAD must be positive or -1")
+		}
+		read = n
+	}
+	return id, read, nil
+}
+
+func fakeXPending(s *fakeStore, args []string) interface{} {
+	key, group := args[0], args[1]
+	_, g, err := s.getGroup(key, group)
+	if err != nil {
+		return err
+	}
+	if g == nil {
+		return errNoGroup(key, group)
+	}
+
+	// summary
+	if len(args) == 2 {
+		if len(g.pending) == 0 {
+			return []interface{}{int64(0), nil, nil, respNilArr{}}
+		}
+		counts := map[string]int64{}
+		for _, p := range g.pending {
+			counts[p.consumer]++
+		}
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		consumers := make([]interface{}, len(names))
+		for i, name := range names {
+			consumers[i] = []interface{}{name, strconv.FormatInt(counts[name], 10)}
+		}
+		return []interface{}{int64(len(g.pending)), g.pending[0].id.String(),
+			g.pending[len(g.pending)-1].id.String(), consumers}
+	}
+
+	// [IDLE min-idle-time] start end count [consumer]
+	args = args[2:]
+	var minIdle time.Duration
+	if strings.EqualFold(args[0], "idle") {
+		if len(args) < 2 {
+			return errSyntax
+		}
+		ms, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errNotInt
+		}
+		minIdle, args = time.Duration(ms)*time.Millisecond, args[2:]
+	}
+	if len(args) != 3 && len(args) != 4 {
+		return errSyntax
+	}
+	lo, ok := parseStreamBound(args[0], false)
+	hi, ok2 := parseStreamBound(args[1], true)
+	if !ok || !ok2 {
+		return errStreamID
+	}
+	count, err2 := strconv.Atoi(args[2])
+	if err2 != nil {
+		return errNotInt
+	}
+
+	now := s.now()
+	replies := []interface{}{}
+	for _, p := range g.pending[g.search(lo):] {
+		if hi.less(p.id) || len(replies) >= count {
+			break
+		}
+		idle := now.Sub(p.delivered)
+		if (len(args) == 4 && p.consumer != args[3]) || idle < minIdle {
+			continue
+		}
+		replies = append(replies, []interface{}{p.id.String(), p.consumer, idle.Milliseconds(), p.count})
+	}
+	return replies
+}
+
+func fakeXClaim(s *fakeStore, args []string) interface{} {
+	key, group, consumer := args[0], args[1], args[2]
+	minIdle, err := strconv.ParseInt(args[3], 10, 64)
+	if err != nil {
+		return errStreamMinIdle
+	}
+
+	var ids []streamID
+	i := 4
+	for ; i < len(args); i++ {
+		id, ok := parseStreamID(args[i], 0)
+		if !ok {
+			break
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return errStreamID
+	}
+
+	now := s.now()
+	delivered, retry := now, int64(-1)
+	var force, justID bool
+	var lastID *streamID
+	for ; i < len(args); i++ {
+		opt := strings.ToLower(args[i])
+		switch opt {
+		case "force":
+			force = true
+			continue
+		case "justid":
+			justID = true
+			continue
+		case "idle", "time", "retrycount", "lastid":
+		default:
+			return respError("ERR Unrecognized XCLAIM option '" + args[i] + "'")
+		}
+		if i+1 >= len(args) {
+			return errSyntax
+		}
+		i++
+		if opt == "lastid" {
+			id, ok := parseStreamID(args[i], 0)
+			if !ok {
+				return errStreamID
+			}
+			lastID = &id
+			continue
+		}
+		n, err := strconv.ParseInt(args[i], 10, 64)
+		if err != nil {
+			return errNotInt
+		}
+		switch opt {
+		case "idle":
+			delivered = now.Add(-time.Duration(n) * time.Millisecond)
+		case "time":
+			delivered = time.Unix(0, n*int64(time.Millisecond))
+		case "retrycount":
+			retry = n
+		}
+	}
+
+	st, g, err := s.getGroup(key, group)
+	if err != nil {
+		return err
+	}
+	if g == nil {
+		return errNoGroup(key, group)
+	}
+	if lastID != nil && g.lastID.less(*lastID) {
+		g.lastID = *lastID
+	}
+
+	replies := []interface{}{}
+	for _, id := range ids {
+		p := g.lookup(id)
+		e, exists := st.entry(id)
+		switch {
+		case p == nil && (!force || !exists):
+			continue
+		case p == nil:
+			// created by FORCE, claimed whatever its idle time as Redis does
+			p = g.deliver(id, consumer, now)
+		case !exists:
+			// deleted from the stream
+			g.ack(id)
+			continue
+		case now.Sub(p.delivered) < time.Duration(minIdle)*time.Millisecond:
+			continue
+		}
+
+		p.consumer, p.delivered = consumer, delivered
+		switch {
+		case retry >= 0:
+			p.count = retry
+		case !justID:
+			p.count++
+		}
+		if justID {
+			replies = append(replies, id.String())
+		} else {
+			replies = append(replies, e.reply())
+		}
+	}
+	c := g.consumer(consumer, now)
+	if len(replies) > 0 {
+		c.active = now
+	}
+	return replies
+}
+
+func fakeXAutoClaim(s *fakeStore, args []string) interface{} {
+	key, group, consumer := args[0], args[1], args[2]
+	minIdle, err := strconv.ParseInt(args[3], 10, 64)
+	if err != nil {
+		return errStreamMinIdle
+	}
+	start, ok := parseStreamBound(args[4], false)
+	if !ok {
+		return errStreamID
+	}
+	count, justID := 100, false
+	for i := 5; i < len(args); i++ {
+		switch {
+		case strings.EqualFold(args[i], "justid"):
+			justID = true
+		case strings.EqualFold(args[i], "count") && i+1 < len(args):
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return respError("ERR COUNT must be > 0")
+			}
+			count, i = n, i+1
+		default:
+			return errSyntax
+		}
+	}
+
+	st, g, err := s.getGroup(key, group)
+	if err != nil {
+		return err
+	}
+	if g == nil {
+		return errNoGroup(key, group)
+	}
+
+	now := s.now()
+	claimed, deleted := []interface{}{}, []interface{}{}
+	next := streamID{}
+	for i := g.search(start); i < len(g.pending); {
+		if len(claimed) == count {
+			next = g.pending[i].id
+			break
+		}
+		p := g.pending[i]
+		e, exists := st.entry(p.id)
+		if !exists {
+			deleted = append(deleted, p.id.String())
+			g.ack(p.id)
+			continue
+		}
+		i++
+		if now.Sub(p.delivered) < time.Duration(minIdle)*time.Millisecond {
+			continue
+		}
+		p.consumer, p.delivered = consumer, now
+		if justID {
+			claimed = append(claimed, p.id.String())
+		} else {
+			p.count++
+			claimed = append(claimed, e.reply())
+		}
+	}
+	c := g.consumer(consumer, now)
+	if len(claimed) > 0 {
+		c.active = now
+	}
+	return []interface{}{next.String(), claimed, deleted}
+}
+
+func fakeXInfo(s *fakeStore, args []string) interface{} {
+	sub := strings.ToLower(args[0])
+	switch {
+	case sub == "groups" && len(args) == 2:
+	case sub == "consumers" && len(args) == 3:
+	case sub == "groups" || sub == "consumers":
+		return errArity("xinfo|" + sub)
+	default:
+		return respError("ERR unknown subcommand '" + args[0] + "'. Try XINFO HELP.")
+	}
+
+	st, err := s.getStream(args[1], false)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		return errNoSuchKey
+	}
+	now := s.now()
+
+	if sub == "consumers" {
+		g := st.groups[args[2]]
+		if g == nil {
+			return errNoGroup(args[1], args[2])
+		}
+		names := make([]string, 0, len(g.consumers))
+		for name := range g.consumers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		replies := make([]interface{}, len(names))
+		for i, name := range names {
+			c := g.consumers[name]
+			inactive := int64(-1)
+			if !c.active.IsZero() {
+				inactive = now.Sub(c.active).Milliseconds()
+			}
+			replies[i] = respMap{"name", name, "pending", g.pendingOf(name),
+				"idle", now.Sub(c.seen).Milliseconds(), "inactive", inactive}
+		}
+		return replies
+	}
+
+	names := make([]string, 0, len(st.groups))
+	for name := range st.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	replies := make([]interface{}, len(names))
+	for i, name := range names {
+		g := st.groups[name]
+		var read, lag interface{}
+		if g.read >= 0 {
+			read, lag = g.read, st.added-g.read
+		}
+		replies[i] = respMap{"name", name, "consumers", int64(len(g.consumers)), "pending", int64(len(g.pending)),
+			"last-delivered-id", g.lastID.String(), "entries-read", read, "lag", lag}
+	}
+	return replies
+}
@@ -0,0 +1,218 @@
+package redismock
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Stream", func() {
+	var (
+		client     *redis.Client
+		clientMock ClientMock
+	)
+
+	BeforeEach(func() {
+		client, clientMock = NewClientFakeMock()
+		clientMock.Clock().Set(time.UnixMilli(1000))
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	add := func(id string, values ...interface{}) string {
+		return client.XAdd(ctx, &redis.XAddArgs{Stream: "orders", ID: id, Values: values}).Val()
+	}
+
+	It("entries", func() {
+		Expect(add("*", "n", "1")).To(Equal("1000-0"))
+		Expect(add("*", "n", "2")).To(Equal("1000-1"))
+		clientMock.Clock().Advance(time.Millisecond)
+		Expect(add("", "n", "3")).To(Equal("1001-0"))
+		Expect(add("1001-*", "n", "4")).To(Equal("1001-1"))
+		Expect(add("2000-5", "n", "5")).To(Equal("2000-5"))
+		Expect(client.XAdd(ctx, &redis.XAddArgs{Stream: "orders", ID: "2000-5", Values: []string{"n", "6"}}).Err()).To(
+			MatchError(HavePrefix("ERR The ID specified in XADD is equal or smaller")))
+		Expect(client.XLen(ctx, "orders").Val()).To(Equal(int64(5)))
+
+		Expect(client.XRange(ctx, "orders", "1000-1", "1001").Val()).To(Equal([]redis.XMessage{
+			{ID: "1000-1", Values: map[string]interface{}{"n": "2"}},
+			{ID: "1001-0", Values: map[string]interface{}{"n": "3"}},
+			{ID: "1001-1", Values: map[string]interface{}{"n": "4"}},
+		}))
+		Expect(client.XRangeN(ctx, "orders", "(1000-1", "+", 1).Val()[0].ID).To(Equal("1001-0"))
+		Expect(client.XRevRangeN(ctx, "orders", "+", "-", 2).Val()).To(HaveLen(2))
+		Expect(client.XRevRange(ctx, "orders", "+", "-").Val()[0].ID).To(Equal("2000-5"))
+
+		Expect(client.XDel(ctx, "orders", "1000-0", "9-9").Val()).To(Equal(int64(1)))
+		Expect(client.XTrimMaxLen(ctx, "orders", 2).Val()).To(Equal(int64(2)))
+		Expect(client.XRange(ctx, "orders", "-", "+").Val()).To(HaveLen(2))
+		client.XAdd(ctx, &redis.XAddArgs{Stream: "orders", MaxLen: 1, Values: []string{"n", "7"}})
+		Expect(client.XLen(ctx, "orders").Val()).To(Equal(int64(1)))
+		Expect(client.Type(ctx, "orders").Val()).To(Equal("stream"))
+
+		Expect(client.XAdd(ctx, &redis.XAddArgs{Stream: "none", NoMkStream: true, Values: []string{"n", "1"}}).Err()).To(
+			Equal(redis.Nil))
+		Expect(client.XRead(ctx, &redis.XReadArgs{Streams: []string{"orders", "$"}}).Err()).To(Equal(redis.Nil))
+		Expect(client.XRead(ctx, &redis.XReadArgs{Streams: []string{"orders", "0"}}).Val()[0].Messages).To(HaveLen(1))
+
+		client.Set(ctx, "string", "value", 0)
+		Expect(client.XLen(ctx, "string").Err()).To(MatchError(msgWrongType))
+	})
+
+	It("groups", func() {
+		Expect(client.XGroupCreate(ctx, "orders", "billing", "$").Err()).To(MatchError(HavePrefix("ERR The XGROUP subcommand")))
+		Expect(client.XGroupCreateMkStream(ctx, "orders", "billing", "$").Val()).To(Equal("OK"))
+		Expect(client.XGroupCreate(ctx, "orders", "billing", "$").Err()).To(
+			MatchError("BUSYGROUP Consumer Group name already exists"))
+
+		first, second := add("*", "n", "1"), add("*", "n", "2")
+		read := func(consumer, id string) ([]redis.XStream, error) {
+			return client.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group: "billing", Consumer: consumer, Streams: []string{"orders", id}, Count: 1,
+			}).Result()
+		}
+
+		streams, err := read("alice", ">")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(streams[0].Messages[0].ID).To(Equal(first))
+		streams, _ = read("bob", ">")
+		Expect(streams[0].Messages[0].ID).To(Equal(second))
+		_, err = read("alice", ">")
+		Expect(err).To(Equal(redis.Nil))
+
+		Expect(client.XPending(ctx, "orders", "billing").Val()).To(Equal(&redis.XPending{
+			Count: 2, Lower: first, Higher: second, Consumers: map[string]int64{"alice": 1, "bob": 1},
+		}))
+
+		// the history of a consumer
+		streams, _ = read("alice", "0")
+		Expect(streams[0].Messages).To(HaveLen(1))
+		Expect(streams[0].Messages[0].ID).To(Equal(first))
+
+		Expect(client.XAck(ctx, "orders", "billing", first, "9-9").Val()).To(Equal(int64(1)))
+		streams, _ = read("alice", "0")
+		Expect(streams[0].Messages).To(BeEmpty())
+
+		Expect(client.XInfoGroups(ctx, "orders").Val()).To(Equal([]redis.XInfoGroup{{
+			Name: "billing", Consumers: 2, Pending: 1, LastDeliveredID: second, EntriesRead: 2, Lag: 0,
+		}}))
+		add("*", "n", "3")
+		Expect(client.XInfoGroups(ctx, "orders").Val()[0].Lag).To(Equal(int64(1)))
+
+		_, err = client.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "none", Consumer: "c", Streams: []string{"orders", ">"}}).Result()
+		Expect(err).To(MatchError(HavePrefix("NOGROUP")))
+
+		Expect(client.XGroupDelConsumer(ctx, "orders", "billing", "bob").Val()).To(Equal(int64(1)))
+		Expect(client.XPending(ctx, "orders", "billing").Val().Count).To(BeZero())
+		Expect(client.XGroupSetID(ctx, "orders", "billing", "0").Val()).To(Equal("OK"))
+		streams, _ = read("carol", ">")
+		Expect(streams[0].Messages[0].ID).To(Equal(first))
+		Expect(client.XGroupDestroy(ctx, "orders", "billing").Val()).To(Equal(int64(1)))
+	})
+
+	It("claims", func() {
+		client.XGroupCreateMkStream(ctx, "orders", "billing", "0")
+		ids := []string{add("*", "n", "1"), add("*", "n", "2"), add("*", "n", "3")}
+		client.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "billing", Consumer: "alice", Streams: []string{"orders", ">"}})
+
+		clientMock.Clock().Advance(time.Minute)
+		pending := client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: "orders", Group: "billing", Idle: time.Minute, Start: "-", End: "+", Count: 10,
+		}).Val()
+		Expect(pending).To(Equal([]redis.XPendingExt{
+			{ID: ids[0], Consumer: "alice", Idle: time.Minute, RetryCount: 1},
+			{ID: ids[1], Consumer: "alice", Idle: time.Minute, RetryCount: 1},
+			{ID: ids[2], Consumer: "alice", Idle: time.Minute, RetryCount: 1},
+		}))
+
+		// the idle time is not reached
+		Expect(client.XClaim(ctx, &redis.XClaimArgs{
+			Stream: "orders", Group: "billing", Consumer: "bob", MinIdle: time.Hour, Messages: ids[:1],
+		}).Val()).To(BeEmpty())
+		claimed := client.XClaim(ctx, &redis.XClaimArgs{
+			Stream: "orders", Group: "billing", Consumer: "bob", MinIdle: time.Minute, Messages: ids[:1],
+		}).Val()
+		Expect(claimed).To(Equal([]redis.XMessage{{ID: ids[0], Values: map[string]interface{}{"n": "1"}}}))
+		Expect(client.XClaimJustID(ctx, &redis.XClaimArgs{
+			Stream: "orders", Group: "billing", Consumer: "bob", Messages: ids[:1],
+		}).Val()).To(Equal(ids[:1]))
+
+		// the deleted entries are removed from the pending entries list
+		client.XDel(ctx, "orders", ids[1])
+		messages, start, err := client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream: "orders", Group: "billing", Consumer: "carol", MinIdle: time.Minute, Start: "-", Count: 1,
+		}).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(messages).To(Equal([]redis.XMessage{{ID: ids[2], Values: map[string]interface{}{"n": "3"}}}))
+		Expect(start).To(Equal("0-0"))
+
+		pending = client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: "orders", Group: "billing", Start: "-", End: "+", Count: 10,
+		}).Val()
+		Expect(pending).To(Equal([]redis.XPendingExt{
+			{ID: ids[0], Consumer: "bob", Idle: 0, RetryCount: 2},
+			{ID: ids[2], Consumer: "carol", Idle: 0, RetryCount: 2},
+		}))
+
+		// FORCE creates the pending entry of an entry never delivered, whatever the idle time
+		forced := add("*", "n", "4")
+		Expect(client.XClaimJustID(ctx, &redis.XClaimArgs{
+			Stream: "orders", Group: "billing", Consumer: "carol", MinIdle: time.Hour, Messages: []string{forced, "9-9"},
+		}).Val()).To(BeEmpty())
+		Expect(client.Do(ctx, "xclaim", "orders", "billing", "carol", 1000, forced, "force").Val()).To(HaveLen(1))
+		pending = client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: "orders", Group: "billing", Start: forced, End: "+", Count: 10,
+		}).Val()
+		Expect(pending).To(Equal([]redis.XPendingExt{{ID: forced, Consumer: "carol", Idle: 0, RetryCount: 2}}))
+		client.XAck(ctx, "orders", "billing", forced)
+
+		consumers := client.XInfoConsumers(ctx, "orders", "billing").Val()
+		Expect(consumers).To(HaveLen(3))
+		Expect(consumers[0].Name).To(Equal("alice"))
+		Expect(consumers[0].Pending).To(BeZero())
+		Expect(consumers[0].Idle).To(Equal(time.Minute))
+		Expect(consumers[2].Name).To(Equal("carol"))
+		Expect(consumers[2].Pending).To(Equal(int64(1)))
+	})
+
+	It("at-least-once", func() {
+		client.XGroupCreateMkStream(ctx, "jobs", "workers", "$")
+		for i := 0; i < 3; i++ {
+			client.XAdd(ctx, &redis.XAddArgs{Stream: "jobs", Values: []string{"job", "work"}})
+		}
+		process := func(consumer string, crash bool) int {
+			var done int
+			for {
+				streams, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+					Group: "workers", Consumer: consumer, Streams: []string{"jobs", ">"}, Count: 2,
+				}).Result()
+				if err == redis.Nil || crash {
+					return done
+				}
+				Expect(err).NotTo(HaveOccurred())
+				for _, msg := range streams[0].Messages {
+					client.XAck(ctx, "jobs", "workers", msg.ID)
+					done++
+				}
+			}
+		}
+
+		// the first worker crashes with two jobs in flight, the second one recovers them
+		Expect(process("w1", true)).To(BeZero())
+		Expect(process("w2", false)).To(Equal(1))
+		clientMock.Clock().Advance(30 * time.Second)
+		messages, _ := client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream: "jobs", Group: "workers", Consumer: "w2", MinIdle: 30 * time.Second, Start: "0",
+		}).Val()
+		Expect(messages).To(HaveLen(2))
+		for _, msg := range messages {
+			client.XAck(ctx, "jobs", "workers", msg.ID)
+		}
+		Expect(client.XPending(ctx, "jobs", "workers").Val().Count).To(BeZero())
+	})
+})
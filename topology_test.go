@@ -0,0 +1,149 @@
+package redismock
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Topology", func() {
+	var (
+		cluster     *Cluster
+		client      *redis.ClusterClient
+		clusterMock ClusterClientMock
+	)
+
+	BeforeEach(func() {
+		var err error
+		cluster, err = NewCluster(
+			ClusterNodeSpec{Name: "master-1", Slots: []SlotRange{{0, 8191}}},
+			ClusterNodeSpec{Name: "master-2", Slots: []SlotRange{{8192, 16383}}},
+			ClusterNodeSpec{Name: "replica-1", ReplicaOf: "master-1"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = redis.NewClusterClient(cluster.Options())
+		clusterMock = cluster.Mock()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(cluster.Close()).NotTo(HaveOccurred())
+		Expect(clusterMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	// masterForKey waits for the client to route the key to the node after a refresh of its slots.
+	masterForKey := func(key, node string) {
+		Eventually(func() string {
+			master, err := client.MasterForKey(ctx, key)
+			Expect(err).NotTo(HaveOccurred())
+			return master.Options().Addr
+		}).Should(Equal(cluster.Addr(node)))
+	}
+
+	It("slots", func() {
+		Expect(keySlot("key")).To(Equal(12539))
+		Expect(keySlot("{user}:1")).To(Equal(keySlot("user")))
+		Expect(keySlot("{}key")).To(Equal(int(crc16("{}key")) % clusterSlots))
+		Expect(keySlot("a{}{b}")).NotTo(Equal(keySlot("b")))
+
+		slots, err := client.ClusterSlots(ctx).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(slots).To(HaveLen(2))
+		Expect(slots[0].Start).To(Equal(0))
+		Expect(slots[0].End).To(Equal(8191))
+		Expect(slots[0].Nodes).To(HaveLen(2))
+		Expect(slots[0].Nodes[1].Addr).To(Equal(cluster.Addr("replica-1")))
+		Expect(slots[1].Nodes[0].Addr).To(Equal(cluster.Addr("master-2")))
+
+		_, err = NewCluster(
+			ClusterNodeSpec{Name: "master-1", Slots: []SlotRange{{0, 100}}},
+			ClusterNodeSpec{Name: "master-2", Slots: []SlotRange{{100, 200}}},
+		)
+		Expect(err).To(MatchError(`redismock: the slot 100 is served by "master-1" and "master-2"`))
+		_, err = NewCluster(ClusterNodeSpec{Name: "replica-1", ReplicaOf: "master-1"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("routes", func() {
+		clusterMock.Node("master-2").ExpectGet("key").SetVal("value")
+		clusterMock.Node("master-1").ExpectGet("user").SetVal("alice")
+
+		Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+		Expect(client.Get(ctx, "user").Val()).To(Equal("alice"))
+		Expect(cluster.Stats().Moved).To(Equal(0))
+
+		// the expectation of another node
+		clusterMock.Node("master-1").ExpectGet("key").SetVal("value")
+		Expect(client.Get(ctx, "key").Err()).To(MatchError(ContainSubstring("was not expected")))
+		clusterMock.ClearExpect()
+	})
+
+	It("scope", func() {
+		// the options of the expectations compose
+		clusterMock.Node("master-2").Regexp().ExpectGet("k.y").SetVal("value")
+		clusterMock.Regexp().Node("master-1").ExpectGet("us.r").SetVal("alice")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("value"))
+		Expect(client.Get(ctx, "user").Val()).To(Equal("alice"))
+
+		clusterMock.Node("master-1").Regexp().ExpectGet("k.y").SetVal("value")
+		Expect(client.Get(ctx, "key").Err()).To(MatchError(ContainSubstring("was not expected")))
+		clusterMock.ClearExpect()
+
+		// the commands of NewClusterMock are received by no node
+		_, mock := NewClusterMock()
+		Expect(func() { mock.Node("master-1") }).To(PanicWith(ContainSubstring("see NewCluster")))
+	})
+
+	It("moved", func() {
+		clusterMock.Node("master-2").ExpectGet("key").SetVal("before")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("before"))
+		requests := cluster.Stats().SlotsRequests
+
+		cluster.MoveSlots(12000, 12999, "master-1")
+		clusterMock.Node("master-1").ExpectGet("key").SetVal("after")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("after"))
+		Expect(cluster.Stats().Moved).To(Equal(1))
+
+		// the client refreshes its slots and sends the commands to the new owner
+		Eventually(func() int { return cluster.Stats().SlotsRequests }).Should(BeNumerically(">", requests))
+		masterForKey("key", "master-1")
+		clusterMock.Node("master-1").ExpectGet("key").SetVal("direct")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("direct"))
+		Expect(cluster.Stats().Moved).To(Equal(1))
+	})
+
+	It("ask", func() {
+		cluster.MigrateSlots(12539, 12539, "master-1")
+		clusterMock.Node("master-1").ExpectGet("key").SetVal("migrating")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("migrating"))
+		Expect(cluster.Stats().Ask).To(Equal(1))
+
+		// without ASKING, the importing node redirects to the owner
+		conn := dialRaw(cluster.node("master-1").srv)
+		defer conn.Close()
+		Expect(conn.do("get", "key")).To(MatchError("MOVED 12539 " + cluster.Addr("master-2")))
+
+		// the owner redirects the client after the migration
+		cluster.MoveSlots(12539, 12539, "master-1")
+		clusterMock.Node("master-1").ExpectGet("key").SetVal("migrated")
+		Expect(client.Get(ctx, "key").Val()).To(Equal("migrated"))
+		Expect(cluster.Stats().Moved).To(Equal(2))
+	})
+
+	It("replica", func() {
+		conn := dialRaw(cluster.node("replica-1").srv)
+		defer conn.Close()
+		Expect(conn.do("get", "user")).To(MatchError("MOVED 5474 " + cluster.Addr("master-1")))
+		Expect(conn.do("cluster", "keyslot", "user")).To(Equal(int64(5474)))
+	})
+
+	It("transaction", func() {
+		conn := dialRaw(cluster.node("master-1").srv)
+		defer conn.Close()
+
+		clusterMock.Node("master-1").ExpectTxPipeline()
+		Expect(conn.do("multi")).To(Equal("OK"))
+		Expect(conn.do("get", "key")).To(MatchError("MOVED 12539 " + cluster.Addr("master-2")))
+		Expect(conn.do("exec")).To(MatchError("EXECABORT Transaction discarded because of previous errors."))
+	})
+})
@@ -0,0 +1,296 @@
+package redismock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// trackingChannel is the Pub/Sub channel of the invalidation messages sent to a REDIRECT client.
+const trackingChannel = "__redis__:invalidate"
+
+// TrackingOptions are the options of CLIENT TRACKING ON.
+type TrackingOptions struct {
+	// Redirect is the ID of the client receiving the invalidation messages
+	// on the __redis__:invalidate channel, required with RESP2.
+	Redirect int64
+	Prefixes []string
+	BCast    bool
+	OptIn    bool
+	OptOut   bool
+	NoLoop   bool
+}
+
+func (opt TrackingOptions) args() []interface{} {
+	var args []interface{}
+	if opt.Redirect != 0 {
+		args = append(args, "redirect", opt.Redirect)
+	}
+	for _, prefix := range opt.Prefixes {
+		args = append(args, "prefix", prefix)
+	}
+	for _, flag := range []struct {
+		set  bool
+		name string
+	}{{opt.BCast, "bcast"}, {opt.OptIn, "optin"}, {opt.OptOut, "optout"}, {opt.NoLoop, "noloop"}} {
+		if flag.set {
+			args = append(args, flag.name)
+		}
+	}
+	return args
+}
+
+// parseTracking reads the arguments of CLIENT TRACKING, the options may be in any order.
+func parseTracking(args []interface{}) (on bool, opt TrackingOptions, err error) {
+	s := formatArgs(args)
+	if len(s) < 3 || !strings.EqualFold(s[1], "tracking") {
+		return false, opt, errors.New("not a CLIENT TRACKING command")
+	}
+	switch strings.ToLower(s[2]) {
+	case "on":
+		on = true
+	case "off":
+	default:
+		return false, opt, fmt.Errorf("CLIENT TRACKING %s, expected ON or OFF", s[2])
+	}
+
+	for i := 3; i < len(s); i++ {
+		switch strings.ToLower(s[i]) {
+		case "redirect", "prefix":
+			if i+1 >= len(s) {
+				return on, opt, fmt.Errorf("CLIENT TRACKING %s without value", s[i])
+			}
+			if strings.EqualFold(s[i], "prefix") {
+				opt.Prefixes = append(opt.Prefixes, s[i+1])
+			} else if opt.Redirect, err = strconv.ParseInt(s[i+1], 10, 64); err != nil {
+				return on, opt, fmt.Errorf("CLIENT TRACKING REDIRECT %s: %w", s[i+1], err)
+			}
+			i++
+		case "bcast":
+			opt.BCast = true
+		case "optin":
+			opt.OptIn = true
+		case "optout":
+			opt.OptOut = true
+		case "noloop":
+			opt.NoLoop = true
+		default:
+			return on, opt, fmt.Errorf("CLIENT TRACKING unknown option %s", s[i])
+		}
+	}
+	sort.Strings(opt.Prefixes)
+	return on, opt, nil
+}
+
+//------------------------------------------------------------------------------
+
+// ExpectedTracking is the expectation of CLIENT TRACKING. Matched by a client of a Server,
+// it records the keys read by the connection and sends the invalidation messages of Invalidate.
+type ExpectedTracking struct {
+	expectedBase
+
+	on  bool
+	opt TrackingOptions
+
+	mu   sync.Mutex
+	srv  *Server
+	conn *respConn
+	keys map[string]struct{}
+}
+
+// ExpectClientTracking expects CLIENT TRACKING ON with the options, or OFF, the options may be sent
+// in any order. The reply is OK, unless SetErr is called.
+func (m *mock) ExpectClientTracking(on bool, opt *TrackingOptions) *ExpectedTracking {
+	e := &ExpectedTracking{on: on}
+	if opt != nil {
+		e.opt = *opt
+		e.opt.Prefixes = append([]string(nil), opt.Prefixes...)
+		sort.Strings(e.opt.Prefixes)
+	}
+
+	mode := "off"
+	if on {
+		mode = "on"
+	}
+	e.cmd = redis.NewStatusCmd(m.ctx, append([]interface{}{"client", "tracking", mode}, e.opt.args()...)...)
+	e.setVal = true
+	e.setCustomMatch(func(_, actual []interface{}) error {
+		gotOn, got, err := parseTracking(actual)
+		if err != nil {
+			return err
+		}
+		if gotOn != e.on || !reflect.DeepEqual(got, e.opt) {
+			return fmt.Errorf("args not match, expectation: '%+v', but gave: '%+v'", e.cmd.Args(), formatArgs(actual))
+		}
+		return nil
+	})
+
+	m.pushExpect(e)
+	return e
+}
+
+func (cmd *ExpectedTracking) inflow(c redis.Cmder) error {
+	return inflow(c, "OK")
+}
+
+// Keys returns the keys read by the client since tracking was enabled and not invalidated yet,
+// the keys are not recorded with BCAST.
+func (cmd *ExpectedTracking) Keys() []string {
+	cmd.mu.Lock()
+	defer cmd.mu.Unlock()
+
+	keys := make([]string, 0, len(cmd.keys))
+	for key := range cmd.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Invalidate tells the client the keys changed. The keys tracked for the client are sent, or with BCAST
+// the keys matching a prefix, in a RESP3 push or a message of the __redis__:invalidate channel to the
+// REDIRECT client. The clients of the hook have no connection, the messages require a Server.
+func (cmd *ExpectedTracking) Invalidate(keys ...string) error {
+	cmd.mu.Lock()
+	var hit []interface{}
+	for _, key := range keys {
+		if cmd.opt.BCast {
+			if cmd.matchPrefix(key) {
+				hit = append(hit, key)
+			}
+		} else if _, ok := cmd.keys[key]; ok {
+			// a key is invalidated once until it is read again
+			delete(cmd.keys, key)
+			hit = append(hit, key)
+		}
+	}
+	cmd.mu.Unlock()
+
+	if len(hit) == 0 {
+		return cmd.check()
+	}
+	return cmd.send(hit)
+}
+
+// InvalidateAll sends the invalidation of all the keys, as Redis does on FLUSHALL.
+func (cmd *ExpectedTracking) InvalidateAll() error {
+	cmd.mu.Lock()
+	cmd.keys = make(map[string]struct{})
+	cmd.mu.Unlock()
+	return cmd.send(nil)
+}
+
+func (cmd *ExpectedTracking) matchPrefix(key string) bool {
+	if len(cmd.opt.Prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range cmd.opt.Prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cmd *ExpectedTracking) check() error {
+	cmd.mu.Lock()
+	defer cmd.mu.Unlock()
+	if cmd.conn == nil {
+		return errors.New("redismock: CLIENT TRACKING ON was not called by a client of a Server")
+	}
+	return nil
+}
+
+func (cmd *ExpectedTracking) send(keys []interface{}) error {
+	if err := cmd.check(); err != nil {
+		return err
+	}
+
+	cmd.mu.Lock()
+	srv, conn := cmd.srv, cmd.conn
+	cmd.mu.Unlock()
+
+	// nil invalidates all the keys
+	var payload interface{} = respNilArr{}
+	if keys != nil {
+		payload = keys
+	}
+
+	if cmd.opt.Redirect != 0 {
+		target := srv.client(cmd.opt.Redirect)
+		if target == nil {
+			return fmt.Errorf("redismock: the REDIRECT client %d is not connected", cmd.opt.Redirect)
+		}
+		if target.deliver(trackingChannel, payload) == 0 {
+			return fmt.Errorf("redismock: the REDIRECT client %d is not subscribed to %s", cmd.opt.Redirect, trackingChannel)
+		}
+		return nil
+	}
+
+	if conn.protocol() != 3 {
+		return errors.New("redismock: RESP2 clients receive the invalidation messages with REDIRECT")
+	}
+	if conn.write(respPush{"invalidate", payload}) != nil {
+		return errors.New("redismock: the tracking client is disconnected")
+	}
+	return nil
+}
+
+// attach starts tracking the connection which matched the expectation, OFF stops it.
+func (cmd *ExpectedTracking) attach(s *Server, c *respConn) {
+	if c.tracking != nil {
+		c.tracking.mu.Lock()
+		c.tracking.conn = nil
+		c.tracking.mu.Unlock()
+		c.tracking = nil
+	}
+	if !cmd.on {
+		return
+	}
+
+	cmd.mu.Lock()
+	cmd.srv, cmd.conn, cmd.keys = s, c, make(map[string]struct{})
+	cmd.mu.Unlock()
+	c.tracking = cmd
+}
+
+// track records the keys read by a command of the tracking connection.
+func (cmd *ExpectedTracking) track(c *respConn, args []string) {
+	caching := c.caching
+	c.caching = ""
+	switch {
+	case cmd.opt.BCast:
+		return
+	case cmd.opt.OptIn && caching != "yes":
+		return
+	case cmd.opt.OptOut && caching == "no":
+		return
+	}
+
+	keys := readKeys(args)
+	if len(keys) == 0 {
+		return
+	}
+	cmd.mu.Lock()
+	for _, key := range keys {
+		cmd.keys[key] = struct{}{}
+	}
+	cmd.mu.Unlock()
+}
+
+// readKeys returns the keys read by a command, Redis tracks the keys of the read-only commands.
+func readKeys(args []string) []string {
+	if len(args) < 2 {
+		return nil
+	}
+	if !readonlyCommand(args[0]) {
+		return nil
+	}
+	return commandKeys(args)
+}
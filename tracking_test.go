@@ -0,0 +1,192 @@
+package redismock
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+// rawConn is a client of a Server reading the replies and the pushed messages itself.
+type rawConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+func dialRaw(srv *Server) *rawConn {
+	conn, err := net.Dial(srv.Network(), srv.Addr())
+	Expect(err).NotTo(HaveOccurred())
+	return &rawConn{Conn: conn, rd: bufio.NewReader(conn)}
+}
+
+func (c *rawConn) do(args ...interface{}) interface{} {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "*%d\r\n", len(args))
+	for _, arg := range args {
+		s := formatArg(arg)
+		fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(s), s)
+	}
+	_, err := c.Write(buf.Bytes())
+	Expect(err).NotTo(HaveOccurred())
+	return c.receive()
+}
+
+// receive reads a reply, decoded as redis.Cmd does.
+func (c *rawConn) receive() interface{} {
+	var buf bytes.Buffer
+	Expect(c.readFrame(&buf)).NotTo(HaveOccurred())
+	val, err := ParseRESP(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return val
+}
+
+func (c *rawConn) readFrame(buf *bytes.Buffer) error {
+	line, err := c.rd.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	buf.WriteString(line)
+
+	n, _ := strconv.Atoi(line[1 : len(line)-2])
+	switch line[0] {
+	case '$', '!', '=':
+		if n >= 0 {
+			_, err = io.CopyN(buf, c.rd, int64(n+2))
+		}
+		return err
+	case '%':
+		n *= 2
+	case '*', '~', '>':
+	default:
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		if err = c.readFrame(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Tracking", func() {
+	var (
+		clientMock ClientMock
+		srv        *Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		_, clientMock = NewClientMock()
+		srv, err = NewServer(clientMock)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(srv.Close()).NotTo(HaveOccurred())
+		Expect(clientMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+
+	It("resp3 push", func() {
+		conn := dialRaw(srv)
+		defer conn.Close()
+		Expect(conn.do("hello", 3)).To(HaveKeyWithValue("proto", int64(3)))
+
+		tracking := clientMock.ExpectClientTracking(true, nil)
+		clientMock.ExpectGet("user:1").SetVal("alice")
+		clientMock.ExpectMGet("user:2", "user:3").SetVal([]interface{}{"bob", nil})
+
+		Expect(conn.do("client", "tracking", "on")).To(Equal("OK"))
+		Expect(conn.do("get", "user:1")).To(Equal("alice"))
+		Expect(conn.do("mget", "user:2", "user:3")).To(Equal([]interface{}{"bob", nil}))
+		Expect(tracking.Keys()).To(Equal([]string{"user:1", "user:2", "user:3"}))
+
+		Expect(tracking.Invalidate("user:1", "user:2", "other")).NotTo(HaveOccurred())
+		Expect(conn.receive()).To(Equal([]interface{}{"invalidate", []interface{}{"user:1", "user:2"}}))
+		Expect(tracking.Keys()).To(Equal([]string{"user:3"}))
+
+		// not tracked anymore, nothing is sent
+		Expect(tracking.Invalidate("user:1")).NotTo(HaveOccurred())
+
+		Expect(tracking.InvalidateAll()).NotTo(HaveOccurred())
+		Expect(conn.receive()).To(Equal([]interface{}{"invalidate", nil}))
+	})
+
+	It("bcast", func() {
+		conn := dialRaw(srv)
+		defer conn.Close()
+		conn.do("hello", 3)
+
+		tracking := clientMock.ExpectClientTracking(true, &TrackingOptions{
+			BCast:    true,
+			Prefixes: []string{"user:", "session:"},
+		})
+		Expect(conn.do("client", "tracking", "on", "prefix", "session:", "bcast", "prefix", "user:")).To(Equal("OK"))
+
+		Expect(tracking.Invalidate("user:7", "order:1", "session:1")).NotTo(HaveOccurred())
+		Expect(conn.receive()).To(Equal([]interface{}{"invalidate", []interface{}{"user:7", "session:1"}}))
+		Expect(tracking.Keys()).To(BeEmpty())
+	})
+
+	It("resp2 redirect", func() {
+		sub := dialRaw(srv)
+		defer sub.Close()
+		id := sub.do("client", "id").(int64)
+		Expect(sub.do("subscribe", trackingChannel)).To(Equal([]interface{}{"subscribe", trackingChannel, int64(1)}))
+
+		opt := srv.Options()
+		opt.Protocol, opt.PoolSize = 2, 1
+		client := redis.NewClient(opt)
+		defer client.Close()
+
+		tracking := clientMock.ExpectClientTracking(true, &TrackingOptions{Redirect: id, OptIn: true})
+		clientMock.ExpectGet("a").SetVal("1")
+		clientMock.ExpectGet("b").SetVal("2")
+
+		Expect(client.Do(ctx, "client", "tracking", "on", "redirect", id, "optin").Err()).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "a").Val()).To(Equal("1"))
+		Expect(client.Do(ctx, "client", "caching", "yes").Err()).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "b").Val()).To(Equal("2"))
+		Expect(tracking.Keys()).To(Equal([]string{"b"}))
+
+		Expect(tracking.Invalidate("a", "b")).NotTo(HaveOccurred())
+		Expect(sub.receive()).To(Equal([]interface{}{"message", trackingChannel, []interface{}{"b"}}))
+	})
+
+	It("errors", func() {
+		opt := srv.Options()
+		opt.Protocol, opt.PoolSize = 2, 1
+		client := redis.NewClient(opt)
+		defer client.Close()
+
+		tracking := clientMock.ExpectClientTracking(true, nil)
+		Expect(tracking.Invalidate("key")).To(MatchError(ContainSubstring("was not called")))
+		Expect(client.Do(ctx, "client", "tracking", "on").Err()).NotTo(HaveOccurred())
+		Expect(tracking.InvalidateAll()).To(MatchError(ContainSubstring("REDIRECT")))
+
+		redirect := clientMock.ExpectClientTracking(true, &TrackingOptions{Redirect: 12345})
+		Expect(client.Do(ctx, "client", "tracking", "on", "redirect", 12345).Err()).NotTo(HaveOccurred())
+		Expect(redirect.InvalidateAll()).To(MatchError(ContainSubstring("not connected")))
+
+		clientMock.ExpectClientTracking(false, nil).SetErr(fmt.Errorf("ERR tracking failed"))
+		Expect(client.Do(ctx, "client", "tracking", "on", "bcast").Err()).To(HaveOccurred())
+		Expect(client.Do(ctx, "client", "tracking", "off").Err()).To(MatchError("ERR tracking failed"))
+	})
+
+	It("hook", func() {
+		client, hookMock := NewClientMock()
+		defer client.Close()
+
+		tracking := hookMock.ExpectClientTracking(true, &TrackingOptions{OptOut: true})
+		Expect(client.Do(ctx, "client", "tracking", "on", "optout").Val()).To(Equal("OK"))
+		Expect(tracking.Invalidate("key")).To(HaveOccurred())
+		Expect(hookMock.ExpectationsWereMet()).NotTo(HaveOccurred())
+	})
+})